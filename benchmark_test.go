@@ -0,0 +1,503 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+type benchmarkRoute struct {
+	method string
+	path   string
+}
+
+type benchmarkTable struct {
+	name   string
+	routes []benchmarkRoute
+	nested bool
+	hosts  int
+}
+
+var benchmarkPlaceholder = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?\}`)
+
+var githubAPI = []benchmarkRoute{
+	{http.MethodGet, "/authorizations"},
+	{http.MethodGet, "/authorizations/{id}"},
+	{http.MethodPost, "/authorizations"},
+	{http.MethodDelete, "/authorizations/{id}"},
+	{http.MethodGet, "/applications/{client_id}/tokens/{access_token}"},
+	{http.MethodDelete, "/applications/{client_id}/tokens"},
+	{http.MethodDelete, "/applications/{client_id}/tokens/{access_token}"},
+	{http.MethodGet, "/events"},
+	{http.MethodGet, "/repos/{owner}/{repo}/events"},
+	{http.MethodGet, "/networks/{owner}/{repo}/events"},
+	{http.MethodGet, "/orgs/{org}/events"},
+	{http.MethodGet, "/users/{user}/received_events"},
+	{http.MethodGet, "/users/{user}/received_events/public"},
+	{http.MethodGet, "/users/{user}/events"},
+	{http.MethodGet, "/users/{user}/events/public"},
+	{http.MethodGet, "/users/{user}/events/orgs/{org}"},
+	{http.MethodGet, "/feeds"},
+	{http.MethodGet, "/notifications"},
+	{http.MethodGet, "/repos/{owner}/{repo}/notifications"},
+	{http.MethodPut, "/notifications"},
+	{http.MethodPut, "/repos/{owner}/{repo}/notifications"},
+	{http.MethodGet, "/notifications/threads/{id}"},
+	{http.MethodGet, "/notifications/threads/{id}/subscription"},
+	{http.MethodPut, "/notifications/threads/{id}/subscription"},
+	{http.MethodDelete, "/notifications/threads/{id}/subscription"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stargazers"},
+	{http.MethodGet, "/users/{user}/starred"},
+	{http.MethodGet, "/user/starred"},
+	{http.MethodGet, "/user/starred/{owner}/{repo}"},
+	{http.MethodPut, "/user/starred/{owner}/{repo}"},
+	{http.MethodDelete, "/user/starred/{owner}/{repo}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/subscribers"},
+	{http.MethodGet, "/users/{user}/subscriptions"},
+	{http.MethodGet, "/user/subscriptions"},
+	{http.MethodGet, "/repos/{owner}/{repo}/subscription"},
+	{http.MethodPut, "/repos/{owner}/{repo}/subscription"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/subscription"},
+	{http.MethodGet, "/users/{user}/gists"},
+	{http.MethodGet, "/gists"},
+	{http.MethodGet, "/gists/{id}"},
+	{http.MethodPost, "/gists"},
+	{http.MethodPut, "/gists/{id}/star"},
+	{http.MethodDelete, "/gists/{id}/star"},
+	{http.MethodGet, "/gists/{id}/star"},
+	{http.MethodPost, "/gists/{id}/forks"},
+	{http.MethodDelete, "/gists/{id}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/git/blobs/{sha}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/git/blobs"},
+	{http.MethodGet, "/repos/{owner}/{repo}/git/commits/{sha}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/git/commits"},
+	{http.MethodGet, "/repos/{owner}/{repo}/git/refs"},
+	{http.MethodPost, "/repos/{owner}/{repo}/git/refs"},
+	{http.MethodGet, "/repos/{owner}/{repo}/git/tags/{sha}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/git/tags"},
+	{http.MethodGet, "/repos/{owner}/{repo}/git/trees/{sha}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/git/trees"},
+	{http.MethodGet, "/issues"},
+	{http.MethodGet, "/user/issues"},
+	{http.MethodGet, "/orgs/{org}/issues"},
+	{http.MethodGet, "/repos/{owner}/{repo}/issues"},
+	{http.MethodGet, "/repos/{owner}/{repo}/issues/{number}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/issues"},
+	{http.MethodGet, "/repos/{owner}/{repo}/assignees"},
+	{http.MethodGet, "/repos/{owner}/{repo}/assignees/{assignee}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/issues/{number}/comments"},
+	{http.MethodPost, "/repos/{owner}/{repo}/issues/{number}/comments"},
+	{http.MethodGet, "/repos/{owner}/{repo}/issues/{number}/events"},
+	{http.MethodGet, "/repos/{owner}/{repo}/labels"},
+	{http.MethodGet, "/repos/{owner}/{repo}/labels/{name}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/labels"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/labels/{name}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/issues/{number}/labels"},
+	{http.MethodPost, "/repos/{owner}/{repo}/issues/{number}/labels"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/issues/{number}/labels/{name}"},
+	{http.MethodPut, "/repos/{owner}/{repo}/issues/{number}/labels"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/issues/{number}/labels"},
+	{http.MethodGet, "/repos/{owner}/{repo}/milestones/{number}/labels"},
+	{http.MethodGet, "/repos/{owner}/{repo}/milestones"},
+	{http.MethodGet, "/repos/{owner}/{repo}/milestones/{number}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/milestones"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/milestones/{number}"},
+	{http.MethodGet, "/emojis"},
+	{http.MethodGet, "/gitignore/templates"},
+	{http.MethodGet, "/gitignore/templates/{name}"},
+	{http.MethodPost, "/markdown"},
+	{http.MethodPost, "/markdown/raw"},
+	{http.MethodGet, "/meta"},
+	{http.MethodGet, "/rate_limit"},
+	{http.MethodGet, "/users/{user}/orgs"},
+	{http.MethodGet, "/user/orgs"},
+	{http.MethodGet, "/orgs/{org}"},
+	{http.MethodGet, "/orgs/{org}/members"},
+	{http.MethodGet, "/orgs/{org}/members/{user}"},
+	{http.MethodDelete, "/orgs/{org}/members/{user}"},
+	{http.MethodGet, "/orgs/{org}/public_members"},
+	{http.MethodGet, "/orgs/{org}/public_members/{user}"},
+	{http.MethodPut, "/orgs/{org}/public_members/{user}"},
+	{http.MethodDelete, "/orgs/{org}/public_members/{user}"},
+	{http.MethodGet, "/orgs/{org}/teams"},
+	{http.MethodGet, "/teams/{id}"},
+	{http.MethodPost, "/orgs/{org}/teams"},
+	{http.MethodDelete, "/teams/{id}"},
+	{http.MethodGet, "/teams/{id}/members"},
+	{http.MethodGet, "/teams/{id}/members/{user}"},
+	{http.MethodPut, "/teams/{id}/members/{user}"},
+	{http.MethodDelete, "/teams/{id}/members/{user}"},
+	{http.MethodGet, "/teams/{id}/repos"},
+	{http.MethodGet, "/teams/{id}/repos/{owner}/{repo}"},
+	{http.MethodPut, "/teams/{id}/repos/{owner}/{repo}"},
+	{http.MethodDelete, "/teams/{id}/repos/{owner}/{repo}"},
+	{http.MethodGet, "/user/teams"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls/{number}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/pulls"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls/{number}/commits"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls/{number}/files"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls/{number}/merge"},
+	{http.MethodPut, "/repos/{owner}/{repo}/pulls/{number}/merge"},
+	{http.MethodGet, "/repos/{owner}/{repo}/pulls/{number}/comments"},
+	{http.MethodPut, "/repos/{owner}/{repo}/pulls/{number}/comments"},
+	{http.MethodGet, "/user/repos"},
+	{http.MethodGet, "/users/{user}/repos"},
+	{http.MethodGet, "/orgs/{org}/repos"},
+	{http.MethodGet, "/repositories"},
+	{http.MethodPost, "/user/repos"},
+	{http.MethodPost, "/orgs/{org}/repos"},
+	{http.MethodGet, "/repos/{owner}/{repo}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/contributors"},
+	{http.MethodGet, "/repos/{owner}/{repo}/languages"},
+	{http.MethodGet, "/repos/{owner}/{repo}/teams"},
+	{http.MethodGet, "/repos/{owner}/{repo}/tags"},
+	{http.MethodGet, "/repos/{owner}/{repo}/branches"},
+	{http.MethodGet, "/repos/{owner}/{repo}/branches/{branch}"},
+	{http.MethodDelete, "/repos/{owner}/{repo}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/collaborators"},
+	{http.MethodGet, "/repos/{owner}/{repo}/collaborators/{user}"},
+	{http.MethodPut, "/repos/{owner}/{repo}/collaborators/{user}"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/collaborators/{user}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/comments"},
+	{http.MethodGet, "/repos/{owner}/{repo}/commits/{sha}/comments"},
+	{http.MethodPost, "/repos/{owner}/{repo}/commits/{sha}/comments"},
+	{http.MethodGet, "/repos/{owner}/{repo}/comments/{id}"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/comments/{id}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/commits"},
+	{http.MethodGet, "/repos/{owner}/{repo}/commits/{sha}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/readme"},
+	{http.MethodGet, "/repos/{owner}/{repo}/contents/{path:.*}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/keys"},
+	{http.MethodGet, "/repos/{owner}/{repo}/keys/{id}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/keys"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/keys/{id}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/downloads"},
+	{http.MethodGet, "/repos/{owner}/{repo}/downloads/{id}"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/downloads/{id}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/forks"},
+	{http.MethodPost, "/repos/{owner}/{repo}/forks"},
+	{http.MethodGet, "/repos/{owner}/{repo}/hooks"},
+	{http.MethodGet, "/repos/{owner}/{repo}/hooks/{id}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/hooks"},
+	{http.MethodPost, "/repos/{owner}/{repo}/hooks/{id}/tests"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/hooks/{id}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/merges"},
+	{http.MethodGet, "/repos/{owner}/{repo}/releases"},
+	{http.MethodGet, "/repos/{owner}/{repo}/releases/{id}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/releases"},
+	{http.MethodDelete, "/repos/{owner}/{repo}/releases/{id}"},
+	{http.MethodGet, "/repos/{owner}/{repo}/releases/{id}/assets"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stats/contributors"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stats/commit_activity"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stats/code_frequency"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stats/participation"},
+	{http.MethodGet, "/repos/{owner}/{repo}/stats/punch_card"},
+	{http.MethodGet, "/repos/{owner}/{repo}/statuses/{ref}"},
+	{http.MethodPost, "/repos/{owner}/{repo}/statuses/{ref}"},
+	{http.MethodGet, "/search/repositories"},
+	{http.MethodGet, "/search/code"},
+	{http.MethodGet, "/search/issues"},
+	{http.MethodGet, "/search/users"},
+	{http.MethodGet, "/legacy/issues/search/{owner}/{repository}/{state}/{keyword}"},
+	{http.MethodGet, "/legacy/repos/search/{keyword}"},
+	{http.MethodGet, "/legacy/user/search/{keyword}"},
+	{http.MethodGet, "/legacy/user/email/{email}"},
+	{http.MethodGet, "/users/{user}"},
+	{http.MethodGet, "/user"},
+	{http.MethodGet, "/users"},
+	{http.MethodGet, "/user/emails"},
+	{http.MethodPost, "/user/emails"},
+	{http.MethodDelete, "/user/emails"},
+	{http.MethodGet, "/users/{user}/followers"},
+	{http.MethodGet, "/user/followers"},
+	{http.MethodGet, "/users/{user}/following"},
+	{http.MethodGet, "/user/following"},
+	{http.MethodGet, "/user/following/{user}"},
+	{http.MethodGet, "/users/{user}/following/{target_user}"},
+	{http.MethodPut, "/user/following/{user}"},
+	{http.MethodDelete, "/user/following/{user}"},
+	{http.MethodGet, "/users/{user}/keys"},
+	{http.MethodGet, "/user/keys"},
+	{http.MethodGet, "/user/keys/{id}"},
+	{http.MethodPost, "/user/keys"},
+	{http.MethodDelete, "/user/keys/{id}"},
+}
+
+var parseAPI = []benchmarkRoute{
+	{http.MethodPost, "/1/classes/{className}"},
+	{http.MethodGet, "/1/classes/{className}/{objectId}"},
+	{http.MethodPut, "/1/classes/{className}/{objectId}"},
+	{http.MethodGet, "/1/classes/{className}"},
+	{http.MethodDelete, "/1/classes/{className}/{objectId}"},
+	{http.MethodPost, "/1/users"},
+	{http.MethodGet, "/1/login"},
+	{http.MethodGet, "/1/users/{objectId}"},
+	{http.MethodPut, "/1/users/{objectId}"},
+	{http.MethodGet, "/1/users"},
+	{http.MethodDelete, "/1/users/{objectId}"},
+	{http.MethodPost, "/1/requestPasswordReset"},
+	{http.MethodPost, "/1/roles"},
+	{http.MethodGet, "/1/roles/{objectId}"},
+	{http.MethodPut, "/1/roles/{objectId}"},
+	{http.MethodGet, "/1/roles"},
+	{http.MethodDelete, "/1/roles/{objectId}"},
+	{http.MethodPost, "/1/files/{fileName}"},
+	{http.MethodPost, "/1/events/{eventName}"},
+	{http.MethodPost, "/1/push"},
+	{http.MethodPost, "/1/installations"},
+	{http.MethodGet, "/1/installations/{objectId}"},
+	{http.MethodPut, "/1/installations/{objectId}"},
+	{http.MethodGet, "/1/installations"},
+	{http.MethodDelete, "/1/installations/{objectId}"},
+	{http.MethodPost, "/1/functions"},
+}
+
+func createStaticRoutes() []benchmarkRoute {
+	routes := make([]benchmarkRoute, 0, 200)
+
+	for section := 0; section < 20; section++ {
+		for page := 0; page < 10; page++ {
+			routes = append(routes, benchmarkRoute{
+				method: http.MethodGet,
+				path:   fmt.Sprintf("/section%d/pages/page%d.html", section, page),
+			})
+		}
+	}
+
+	return routes
+}
+
+func createParamRoutes() []benchmarkRoute {
+	routes := make([]benchmarkRoute, 0, 100)
+
+	for index := 0; index < 100; index++ {
+		routes = append(routes, benchmarkRoute{
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/r%d/{a}/{b:[0-9]+}/{c}/{d:[a-z0-9]+}/{e}", index),
+		})
+	}
+
+	return routes
+}
+
+func createNestedRoutes() []benchmarkRoute {
+	routes := make([]benchmarkRoute, 0, 50)
+
+	for level := 0; level < 10; level++ {
+		for index := 0; index < 5; index++ {
+			routes = append(routes, benchmarkRoute{
+				method: http.MethodGet,
+				path:   fmt.Sprintf("/item%d/{id%d}", index, index),
+			})
+		}
+	}
+
+	return routes
+}
+
+var benchmarkTables = []benchmarkTable{
+	{name: "GitHub", routes: githubAPI},
+	{name: "Parse", routes: parseAPI},
+	{name: "Static", routes: createStaticRoutes()},
+	{name: "Params", routes: createParamRoutes()},
+	{name: "Nested", routes: createNestedRoutes(), nested: true},
+	{name: "Hosts", routes: parseAPI, hosts: 20},
+}
+
+func (t benchmarkTable) register(b *testing.B) (Router, []string) {
+	r := New()
+	names := make([]string, 0, len(t.routes))
+
+	for host := 0; host < t.hosts; host++ {
+		for index, route := range t.routes {
+			name := fmt.Sprintf("host%d.route%d", host, index)
+
+			err := r.AddRoute(name, route.path, route.method, nil, Options{
+				Host: fmt.Sprintf("host%d.example.com", host),
+			})
+			if err != nil {
+				b.Fatalf(`not expected error but got %v`, err)
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	if t.hosts > 0 {
+		return r, names
+	}
+
+	var group RouteGroup = r
+	prefix := ""
+
+	for index, route := range t.routes {
+		if t.nested && index > 0 && index%5 == 0 {
+			level := index / 5
+
+			next, err := group.AddRouteGroup(fmt.Sprintf("level%d", level), fmt.Sprintf("/level%d/{level%d}", level, level), Options{})
+			if err != nil {
+				b.Fatalf(`not expected error but got %v`, err)
+			}
+
+			group = next
+			prefix = fmt.Sprintf("%slevel%d.", prefix, level)
+		}
+
+		name := fmt.Sprintf("route%d", index)
+
+		err := group.AddRoute(name, route.path, route.method, nil, Options{})
+		if err != nil {
+			b.Fatalf(`not expected error but got %v`, err)
+		}
+
+		names = append(names, prefix+name)
+	}
+
+	return r, names
+}
+
+func (t benchmarkTable) requests(b *testing.B, r Router, names []string) ([]*http.Request, []Route, []ParamsMap) {
+	requests := make([]*http.Request, 0, len(names))
+	routes := make([]Route, 0, len(names))
+	params := make([]ParamsMap, 0, len(names))
+
+	for _, name := range names {
+		route, ok := r.FindRouteByName(name)
+		if !ok {
+			b.Fatalf(`expected route "%s" to be found`, name)
+		}
+
+		values := ParamsMap{}
+		for _, param := range benchmarkPlaceholder.FindAllStringSubmatch(route.Template(), -1) {
+			values[param[1]] = "1"
+		}
+
+		result, err := route.URL(values)
+		if err != nil {
+			b.Fatalf(`not expected error but got %v`, err)
+		}
+
+		request := httptest.NewRequest(route.Methods()[0], result.String(), nil)
+
+		requests = append(requests, request)
+		routes = append(routes, route)
+		params = append(params, values)
+	}
+
+	return requests, routes, params
+}
+
+func BenchmarkRouter_AddRoute(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				table.register(b)
+			}
+		})
+	}
+}
+
+func BenchmarkRouter_FindRouteByRequest(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+			requests, _, _ := table.requests(b, r, names)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.FindRouteByRequest(requests[i%len(requests)])
+			}
+		})
+	}
+}
+
+func BenchmarkRouter_MatchParams(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+			requests, _, _ := table.requests(b, r, names)
+
+			var params Params
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = r.MatchParams(requests[i%len(requests)], &params)
+			}
+		})
+	}
+}
+
+func BenchmarkRouter_FindRouteByName(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.FindRouteByName(names[i%len(names)])
+			}
+		})
+	}
+}
+
+func BenchmarkRoute_ExtractParams(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+			requests, routes, _ := table.requests(b, r, names)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				index := i % len(routes)
+				_, _ = routes[index].ExtractParams(requests[index])
+			}
+		})
+	}
+}
+
+func BenchmarkRoute_URL(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+			_, routes, params := table.requests(b, r, names)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				index := i % len(routes)
+				_, _ = routes[index].URL(params[index])
+			}
+		})
+	}
+}
+
+func BenchmarkRoute_UncheckedURL(b *testing.B) {
+	for _, table := range benchmarkTables {
+		b.Run(table.name, func(b *testing.B) {
+			r, names := table.register(b)
+			_, routes, params := table.requests(b, r, names)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				index := i % len(routes)
+				_, _ = routes[index].UncheckedURL(params[index])
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ type builder struct {
 	secure           bool
 	host             string
 	paramRequirement string
+	basePath         string
+	stripBasePath    bool
+	collectErrors    bool
 }
 
 func NewBuilder() Builder {
@@ -32,13 +35,28 @@ func (b *builder) SetDefaultParamRequirement(expr string) Builder {
 	return b
 }
 
+func (b *builder) SetBasePath(path string) Builder {
+	b.basePath = path
+	return b
+}
+
+func (b *builder) SetStripBasePath(strip bool) Builder {
+	b.stripBasePath = strip
+	return b
+}
+
+func (b *builder) SetCollectErrors(collect bool) Builder {
+	b.collectErrors = collect
+	return b
+}
+
 func (b *builder) Build() (Router, error) {
 	paramRequirementCompiled, err := regexp.Compile(b.paramRequirement)
 	if err != nil {
-		return nil, fmt.Errorf(`error while compiling regexp for param requirement "%s": %w`, b.paramRequirement, err)
+		return nil, &PatternError{Pattern: b.paramRequirement, Cause: err}
 	}
 
-	factory := newFactory(paramRequirementCompiled)
+	factory := newFactory(paramRequirementCompiled, newBasePath(b.basePath, b.stripBasePath), b.collectErrors)
 
 	group, err := factory.createRouteGroup("", "/", Options{
 		Secure: b.secure,
@@ -48,6 +66,8 @@ func (b *builder) Build() (Router, error) {
 		return nil, fmt.Errorf(`error while creating root group: %w`, err)
 	}
 
+	factory.root = group
+
 	return &router{
 		factory: factory,
 		group:   group,
@@ -0,0 +1,339 @@
+package router
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type ClientSegment struct {
+	Value string `json:"value,omitempty"`
+	Param string `json:"param,omitempty"`
+}
+
+// ClientRoute holds what a client needs to build the same URLs as Route.URL.
+// Requirements are exported as written, so they should stay within the syntax
+// shared by Go and JavaScript regular expressions. A localized route is
+// exported with its default locale and the names of its locale routes.
+type ClientRoute struct {
+	Path         []ClientSegment   `json:"path"`
+	Host         []ClientSegment   `json:"host,omitempty"`
+	Secure       bool              `json:"secure,omitempty"`
+	Requirements map[string]string `json:"requirements,omitempty"`
+	Defaults     ParamsMap         `json:"defaults,omitempty"`
+	Locales      map[string]string `json:"locales,omitempty"`
+}
+
+type ClientRoutes map[string]ClientRoute
+
+// ExportClientRoutes collects the named routes of the router for URL
+// generation on the client. When prefixes are provided, only routes with a
+// name starting with one of them as whole dot separated segments are exported.
+func ExportClientRoutes(r Router, prefixes ...string) (ClientRoutes, error) {
+	source, ok := r.(*router)
+	if !ok {
+		return nil, errors.New("client routes can be exported only from routers created by the builder")
+	}
+
+	source.group.lock.RLock()
+	defer source.group.lock.RUnlock()
+
+	result := ClientRoutes{}
+	result.collect(source.group, prefixes)
+
+	return result, nil
+}
+
+func (c ClientRoutes) collect(finder routeFinder, prefixes []string) {
+	switch child := finder.(type) {
+	case *route:
+		if hasNamePrefix(child.name, prefixes) {
+			c[child.name] = newClientRoute(child)
+		}
+	case *routeGroup:
+		for _, r := range child.routes {
+			c.collect(r, prefixes)
+		}
+	case *versionedRouteGroup:
+		for _, r := range child.base.routes {
+			c.collect(r, prefixes)
+		}
+	case *localizedRoute:
+		for _, locale := range child.localesList {
+			c.collect(child.locales[locale], prefixes)
+		}
+
+		if !hasNamePrefix(child.name, prefixes) {
+			return
+		}
+
+		route := newClientRoute(child.locales[child.defaultLocale])
+		route.Locales = make(map[string]string, len(child.locales))
+		for locale := range child.locales {
+			route.Locales[locale] = fmt.Sprintf("%s.%s", child.name, locale)
+		}
+
+		c[child.name] = route
+	}
+}
+
+func hasNamePrefix(name string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, ".")
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newClientRoute(r *route) ClientRoute {
+	segments := r.urlSegments
+	if segments == nil {
+		segments = r.segments.withPrefix(r.basePath.value)
+	}
+
+	result := ClientRoute{
+		Path:         newClientSegments(segments),
+		Secure:       r.secure,
+		Requirements: map[string]string{},
+	}
+
+	if r.host != "" {
+		result.Host = newClientSegments(r.hostSegments)
+		if result.Host == nil {
+			result.Host = []ClientSegment{{Value: r.host}}
+		}
+	}
+
+	for _, key := range append(append(paramsList{}, r.requiredParams...), r.hostParams...) {
+		requirement, ok := r.paramsRequirements[key]
+		if !ok {
+			requirement = r.requirement
+		}
+
+		result.Requirements[key] = requirement.String()
+	}
+
+	if len(r.defaultParams) > 0 {
+		result.Defaults = r.DefaultParams()
+	}
+
+	return result
+}
+
+func newClientSegments(template pathTemplate) []ClientSegment {
+	var result []ClientSegment
+
+	for _, segment := range template {
+		if segment.param {
+			result = append(result, ClientSegment{Param: segment.value})
+			continue
+		}
+
+		if last := len(result) - 1; last >= 0 && result[last].Param == "" {
+			result[last].Value += segment.value
+			continue
+		}
+
+		result = append(result, ClientSegment{Value: segment.value})
+	}
+
+	return result
+}
+
+func (c ClientRoutes) MarshalIndent() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// WriteTypeScript writes a module exposing the routes together with a typed
+// url helper, which validates and escapes params the same way Route.URL does.
+func (c ClientRoutes) WriteTypeScript(w io.Writer) error {
+	data, err := c.MarshalIndent()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writer := bufio.NewWriter(w)
+
+	fmt.Fprint(writer, clientModuleHeader)
+	fmt.Fprintf(writer, "export const routes: Record<RouteName, RouteDefinition> = %s;\n\n", data)
+
+	fmt.Fprint(writer, "export interface RouteParams {\n")
+	for _, name := range names {
+		quoted, _ := json.Marshal(name)
+		fmt.Fprintf(writer, "  %s: { %s };\n", quoted, c.paramsType(name))
+	}
+	fmt.Fprint(writer, "}\n\n")
+
+	fmt.Fprint(writer, clientModuleFooter)
+
+	return writer.Flush()
+}
+
+// paramsType lists the params of the route. A localized route accepts the
+// params of its locale routes, which are required only if every locale
+// requires them.
+func (c ClientRoutes) paramsType(name string) string {
+	routes := []ClientRoute{c[name]}
+	if locales := c[name].Locales; len(locales) > 0 {
+		routes = routes[:0]
+		for _, localeName := range locales {
+			routes = append(routes, c[localeName])
+		}
+	}
+
+	required := map[string]int{}
+	for _, route := range routes {
+		for key := range route.Requirements {
+			count := required[key]
+			if _, ok := route.Defaults[key]; !ok {
+				count++
+			}
+
+			required[key] = count
+		}
+	}
+
+	if len(c[name].Locales) > 0 {
+		required[LocaleParam] = 0
+	}
+
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		if required[key] < len(routes) {
+			fields = append(fields, fmt.Sprintf("%s?: ParamValue;", key))
+			continue
+		}
+
+		fields = append(fields, fmt.Sprintf("%s: ParamValue;", key))
+	}
+
+	fields = append(fields, fmt.Sprintf("%s?: string;", FragmentParam))
+
+	return strings.Join(fields, " ")
+}
+
+const clientModuleHeader = `// Code generated by router. DO NOT EDIT.
+
+export type ParamValue = string | number;
+
+export interface Segment {
+  value?: string;
+  param?: string;
+}
+
+export interface RouteDefinition {
+  path: Segment[];
+  host?: Segment[];
+  secure?: boolean;
+  requirements?: Record<string, string>;
+  defaults?: Record<string, string>;
+  locales?: Record<string, string>;
+}
+
+`
+
+const clientModuleFooter = `export type RouteName = keyof RouteParams;
+
+type Params = Record<string, ParamValue | undefined>;
+
+type UrlArgs<N extends RouteName> = {} extends RouteParams[N] ? [params?: RouteParams[N]] : [params: RouteParams[N]];
+
+function lookup(route: RouteDefinition, params: Params, key: string): string | undefined {
+  const value = params[key];
+  if (value !== undefined && value !== null) {
+    return String(value);
+  }
+
+  return route.defaults?.[key];
+}
+
+function escapeParam(value: string): string {
+  return encodeURIComponent(value)
+    .replace(/[!'()*]/g, (c) => "%" + c.charCodeAt(0).toString(16).toUpperCase())
+    .replace(/%(24|26|2B|2F|3A|3D|40)/g, (_, hex: string) => String.fromCharCode(parseInt(hex, 16)));
+}
+
+function build(route: RouteDefinition, segments: Segment[], params: Params, escape: boolean): string {
+  let result = "";
+
+  for (const segment of segments) {
+    if (segment.param === undefined) {
+      result += segment.value ?? "";
+      continue;
+    }
+
+    const value = lookup(route, params, segment.param) ?? "";
+    result += escape ? escapeParam(value) : value;
+  }
+
+  return result;
+}
+
+export function url<N extends RouteName>(name: N, ...args: UrlArgs<N>): string {
+  return generate(name, ((args as unknown[])[0] ?? {}) as Params);
+}
+
+function generate(name: string, params: Params): string {
+  const route: RouteDefinition | undefined = (routes as Record<string, RouteDefinition>)[name];
+  if (route === undefined) {
+    throw new Error("route \"" + name + "\" not found");
+  }
+
+  if (route.locales !== undefined) {
+    const locale = lookup(route, params, "` + LocaleParam + `") || route.defaults?.["` + LocaleParam + `"] || "";
+    if (!Object.prototype.hasOwnProperty.call(route.locales, locale)) {
+      throw new Error("invalid param \"` + LocaleParam + `\" for route \"" + name + "\"");
+    }
+
+    return generate(route.locales[locale], params);
+  }
+
+  for (const [key, requirement] of Object.entries(route.requirements ?? {})) {
+    const value = lookup(route, params, key);
+    if (value === undefined) {
+      throw new Error("missing param \"" + key + "\" for route \"" + name + "\"");
+    }
+
+    const match = new RegExp(requirement, "y").exec(value);
+    if (match === null || match[0].length !== value.length) {
+      throw new Error("invalid param \"" + key + "\" for route \"" + name + "\"");
+    }
+  }
+
+  let result = build(route, route.path, params, true);
+
+  if (route.host !== undefined) {
+    result = (route.secure ? "https" : "http") + "://" + build(route, route.host, params, false) + result;
+  }
+
+  const fragment = lookup(route, params, "` + FragmentParam + `");
+  if (fragment) {
+    result += "#" + encodeURI(fragment).replace(/#/g, "%23");
+  }
+
+  return result;
+}
+`
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportClientRoutes(t *testing.T) {
+	r, err := NewBuilder().SetBasePath("/app").Build()
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	api, err := r.AddRouteGroup("api", "/api", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddGetRoute("user", "/users/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	apiary, err := r.AddRouteGroup("apiary", "/apiary", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = apiary.AddGetRoute("hive", "/hives/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("tenant", "/pages/{page}", "", nil, Options{
+		Host:          "{tenant}.domain.com",
+		Secure:        true,
+		DefaultParams: ParamsMap{"page": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("internal", "/internal", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddLocalizedRoute("product", map[string]string{
+		"de": "/produkt/{slug}",
+		"en": "/product/{slug}",
+	}, []string{http.MethodGet}, nil, LocalizedOptions{DefaultLocale: "en"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	routes, err := ExportClientRoutes(r, "api.", "tenant", "product")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	expected := ClientRoutes{
+		"api.user": {
+			Path:         []ClientSegment{{Value: "/app/api/users/"}, {Param: "id"}},
+			Requirements: map[string]string{"id": "([0-9]+)"},
+		},
+		"tenant": {
+			Path:         []ClientSegment{{Value: "/app/pages/"}, {Param: "page"}},
+			Host:         []ClientSegment{{Param: "tenant"}, {Value: ".domain.com"}},
+			Secure:       true,
+			Requirements: map[string]string{"page": DefaultParamRequirement, "tenant": defaultHostParamRequirement},
+			Defaults:     ParamsMap{"page": "home"},
+		},
+		"product": {
+			Path:         []ClientSegment{{Value: "/app/product/"}, {Param: "slug"}},
+			Requirements: map[string]string{"slug": DefaultParamRequirement},
+			Defaults:     ParamsMap{LocaleParam: "en"},
+			Locales:      map[string]string{"de": "product.de", "en": "product.en"},
+		},
+		"product.de": {
+			Path:         []ClientSegment{{Value: "/app/produkt/"}, {Param: "slug"}},
+			Requirements: map[string]string{"slug": DefaultParamRequirement},
+			Defaults:     ParamsMap{LocaleParam: "de"},
+		},
+		"product.en": {
+			Path:         []ClientSegment{{Value: "/app/product/"}, {Param: "slug"}},
+			Requirements: map[string]string{"slug": DefaultParamRequirement},
+			Defaults:     ParamsMap{LocaleParam: "en"},
+		},
+	}
+
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf(`expected routes %v but got %v`, expected, routes)
+	}
+
+	prefixed, err := ExportClientRoutes(r, "api")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, ok := prefixed["apiary.hive"]; ok || len(prefixed) != 1 {
+		t.Errorf(`expected only "api.user" but got %v`, prefixed)
+	}
+
+	all, err := ExportClientRoutes(r)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if len(all) != 7 {
+		t.Errorf(`expected 7 routes but got %v`, all)
+	}
+}
+
+func TestClientRoutes_WriteTypeScript(t *testing.T) {
+	r, err := NewBuilder().SetBasePath("/app").Build()
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	api, err := r.AddRouteGroup("api", "/api", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddGetRoute("user", "/users/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("tenant", "/pages/{page}", "", nil, Options{
+		Host:          "{tenant}.domain.com",
+		Secure:        true,
+		DefaultParams: ParamsMap{"page": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("internal", "/internal", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddLocalizedRoute("product", map[string]string{
+		"de": "/{category}/{slug}",
+		"en": "/product/{slug}",
+	}, []string{http.MethodGet}, nil, LocalizedOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	routes, err := ExportClientRoutes(r)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	var buffer bytes.Buffer
+	if err := routes.WriteTypeScript(&buffer); err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	result := buffer.String()
+
+	for _, expected := range []string{
+		`"api.user": { id: ParamValue; _fragment?: string; };`,
+		`"internal": { _fragment?: string; };`,
+		`"tenant": { page?: ParamValue; tenant: ParamValue; _fragment?: string; };`,
+		`"product": { category?: ParamValue; locale?: ParamValue; slug: ParamValue; _fragment?: string; };`,
+		`"product.de": { category: ParamValue; slug: ParamValue; _fragment?: string; };`,
+		`"param": "id"`,
+		`"de": "product.de"`,
+		`locales?: Record<string, string>;`,
+		`export function url<N extends RouteName>(name: N, ...args: UrlArgs<N>): string {`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf(`expected module to contain %s but got %s`, expected, result)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ompluscator/router"
+)
+
+const usage = `usage: routerctl -f <file> <command> [arguments]
+
+commands:
+  list                     list routes in the order they are matched
+  match <method> <url>     print the route and params matched by the request
+  url <name> [key=value]   generate the URL of the route
+  lint                     report duplicate names, invalid patterns and shadowed routes
+`
+
+var errLint = errors.New("lint failed")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("routerctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+	}
+
+	file := flags.String("f", "routes.json", "route definition file")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	table, err := loadTable(*file)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	command, arguments := flags.Arg(0), flags.Args()[1:]
+
+	switch command {
+	case "list":
+		err = list(stdout, table)
+	case "match":
+		err = match(stdout, table, arguments)
+	case "url":
+		err = generate(stdout, table, arguments)
+	case "lint":
+		err = lint(stdout, table)
+	default:
+		flags.Usage()
+		return 2
+	}
+
+	if errors.Is(err, errLint) {
+		return 1
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func loadTable(file string) (router.RouteTable, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return router.RouteTable{}, err
+	}
+
+	return router.UnmarshalRouteTable(data)
+}
+
+func list(stdout io.Writer, table router.RouteTable) error {
+	r, err := table.Build(nil)
+	if err != nil {
+		return err
+	}
+
+	hosts := map[string]string{}
+	for _, definition := range table.Routes {
+		hosts[definition.Name] = definition.Host
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tMETHODS\tHOST\tPATH\tPRIORITY")
+
+	for _, route := range r.OrderedRoutes() {
+		methods := strings.Join(route.Methods(), ",")
+		if methods == "" {
+			methods = "ANY"
+		}
+
+		host := hosts[route.Name()]
+		if host == "" {
+			host = "-"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\n", route.Name(), methods, host, route.Template(), route.Priority())
+	}
+
+	return writer.Flush()
+}
+
+func match(stdout io.Writer, table router.RouteTable, arguments []string) error {
+	if len(arguments) != 2 {
+		return errors.New("match expects a method and a URL")
+	}
+
+	r, err := table.Build(nil)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Match(httptest.NewRequest(strings.ToUpper(arguments[0]), arguments[1], nil))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "route\t%s\n", result.Route.Name())
+	fmt.Fprintf(stdout, "path\t%s\n", result.Route.Template())
+
+	params := result.Params()
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(stdout, "param\t%s=%s\n", key, params[key])
+	}
+
+	return nil
+}
+
+func generate(stdout io.Writer, table router.RouteTable, arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New("url expects a route name")
+	}
+
+	r, err := table.Build(nil)
+	if err != nil {
+		return err
+	}
+
+	route, ok := r.FindRouteByName(arguments[0])
+	if !ok {
+		return &router.RouteError{Route: arguments[0], Err: router.ErrRouteNotFound}
+	}
+
+	params := router.ParamsMap{}
+	for _, argument := range arguments[1:] {
+		index := strings.Index(argument, "=")
+		if index < 0 {
+			return fmt.Errorf(`param "%s" is not in key=value format`, argument)
+		}
+
+		params[argument[:index]] = argument[index+1:]
+	}
+
+	result, err := route.URL(params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, result)
+
+	return nil
+}
+
+func lint(stdout io.Writer, table router.RouteTable) error {
+	var problems []string
+
+	valid := table
+	valid.Routes = nil
+
+	r, err := valid.Build(nil)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+
+	// each definition is added to the same router, so redirects and aliases
+	// find the valid definitions before them
+	for _, definition := range table.Routes {
+		if definition.Kind != router.RouteKindAlias {
+			if seen[definition.Name] {
+				problems = append(problems, fmt.Sprintf(`%s: duplicate route name`, definition.Name))
+				continue
+			}
+			seen[definition.Name] = true
+		}
+
+		if err := definition.Restore(r, nil); err != nil {
+			problems = append(problems, fmt.Sprintf(`%s: %v`, definition.Name, err))
+		}
+	}
+
+	for _, route := range r.Routes() {
+		if winner, request, ok := findShadowingRoute(r, route, table); ok {
+			problems = append(problems, fmt.Sprintf(`%s: shadowed by "%s" for %s %s`, route.Name(), winner.Name(), request.Method, request.URL))
+		}
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintln(stdout, problem)
+	}
+
+	if len(problems) > 0 {
+		return errLint
+	}
+
+	return nil
+}
+
+var probeValues = []string{"1", "a", "a-1"}
+
+// findShadowingRoute builds a sample request for the route and reports which
+// route the router resolves instead, if that request would match both.
+func findShadowingRoute(r router.Router, route router.Route, table router.RouteTable) (router.Route, *http.Request, bool) {
+	method := http.MethodGet
+	if methods := route.Methods(); len(methods) > 0 {
+		method = methods[0]
+	}
+
+	for _, value := range probeValues {
+		result, err := probeURL(route, value)
+		if err != nil {
+			continue
+		}
+
+		if table.BasePath != "" && !table.StripBasePath {
+			result.Path = strings.TrimPrefix(result.Path, "/"+strings.Trim(table.BasePath, "/"))
+			result.RawPath = ""
+		}
+
+		request := httptest.NewRequest(method, result.String(), nil)
+		if _, err := route.ExtractParams(request); err != nil {
+			continue
+		}
+
+		winner, ok := r.FindRouteByRequest(request)
+		if !ok || winner.Name() == route.Name() {
+			return nil, nil, false
+		}
+
+		return winner, request, true
+	}
+
+	return nil, nil, false
+}
+
+func probeURL(route router.Route, value string) (*url.URL, error) {
+	params := router.ParamsMap{}
+
+	for {
+		result, err := route.UncheckedURL(params)
+
+		var paramErr *router.ParamError
+		if !errors.As(err, &paramErr) || !errors.Is(paramErr.Err, router.ErrMissingParam) {
+			return result, err
+		}
+
+		if _, ok := params[paramErr.Param]; ok {
+			return nil, err
+		}
+
+		params[paramErr.Param] = value
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		args     []string
+		code     int
+		expected []string
+	}{
+		{
+			args:     []string{"-f", "testdata/routes.json", "list"},
+			expected: []string{"NAME", "users.update  PATCH,PUT  -", "{tenant}.domain.com  /pages/{page}"},
+		},
+		{
+			args:     []string{"-f", "testdata/routes.json", "match", "get", "https://acme.domain.com/pages/about"},
+			expected: []string{"route\ttenant\n", "param\tpage=about\n", "param\ttenant=acme\n"},
+		},
+		{
+			args:     []string{"-f", "testdata/routes.json", "match", "PUT", "/users/10"},
+			expected: []string{"route\tusers.update\n", "param\tid=10\n"},
+		},
+		{
+			args: []string{"-f", "testdata/routes.json", "match", "DELETE", "/users/10"},
+			code: 1,
+		},
+		{
+			args:     []string{"-f", "testdata/routes.json", "url", "users.show", "id=10", "_fragment=top"},
+			expected: []string{"/users/10#top\n"},
+		},
+		{
+			args:     []string{"-f", "testdata/routes.json", "url", "tenant", "tenant=acme"},
+			expected: []string{"https://acme.domain.com/pages/home\n"},
+		},
+		{
+			args: []string{"-f", "testdata/routes.json", "url", "users.show", "id=abc"},
+			code: 1,
+		},
+		{
+			args: []string{"-f", "testdata/routes.json", "url", "users.missing"},
+			code: 1,
+		},
+		{
+			args: []string{"-f", "testdata/routes.json", "lint"},
+		},
+		{
+			args: []string{"-f", "testdata/invalid.json", "lint"},
+			code: 1,
+			expected: []string{
+				"users.show: duplicate route name\n",
+				`broken: invalid pattern "[0-9"`,
+				`users.me: shadowed by "users.show" for GET /users/a`,
+				`members: route "users.missing": route not found`,
+				`moved: route "moved": invalid redirect status 200`,
+			},
+		},
+		{
+			args: []string{"-f", "testdata/missing.json", "list"},
+			code: 1,
+		},
+		{
+			args: []string{"-f", "testdata/routes.json", "unknown"},
+			code: 2,
+		},
+	}
+
+	for _, c := range cases {
+		var stdout, stderr bytes.Buffer
+
+		code := run(c.args, &stdout, &stderr)
+		if code != c.code {
+			t.Errorf(`expected code %d for %v but got %d with %s`, c.code, c.args, code, stderr.String())
+		}
+
+		for _, expected := range c.expected {
+			if !strings.Contains(stdout.String(), expected) {
+				t.Errorf(`expected output for %v to contain "%s" but got %s`, c.args, expected, stdout.String())
+			}
+		}
+	}
+}
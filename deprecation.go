@@ -0,0 +1,40 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Deprecation struct {
+	Since     time.Time `json:"since"`
+	Sunset    time.Time `json:"sunset"`
+	Successor string    `json:"successor,omitempty"`
+}
+
+type DeprecationHook func(route Route, request *http.Request)
+
+func (d *Deprecation) copy() *Deprecation {
+	if d == nil {
+		return nil
+	}
+
+	result := *d
+	return &result
+}
+
+func (d Deprecation) writeHeaders(header http.Header, successor string) {
+	if d.Since.IsZero() {
+		header.Set("Deprecation", "true")
+	} else {
+		header.Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+	}
+
+	if !d.Sunset.IsZero() {
+		header.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if successor != "" {
+		header.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+	}
+}
@@ -0,0 +1,160 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrURLNotProvided   = errors.New("url is not provided")
+	ErrURLMismatch      = errors.New("url does not belong to route")
+	ErrRouteNotFound    = errors.New("route not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrHostNotProvided  = errors.New("host is not provided")
+	ErrDuplicateRoute   = errors.New("duplicate route")
+	ErrMissingParam     = errors.New("missing param")
+	ErrInvalidParam     = errors.New("invalid param")
+	ErrInvalidPattern   = errors.New("invalid pattern")
+)
+
+type RouteError struct {
+	Route string
+	Err   error
+}
+
+func (e *RouteError) Error() string {
+	if e.Err == ErrDuplicateRoute {
+		return fmt.Sprintf(`route with name "%s" already exists`, e.Route)
+	}
+
+	return fmt.Sprintf(`route "%s": %v`, e.Route, e.Err)
+}
+
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
+type ParamError struct {
+	Route       string
+	Param       string
+	Value       string
+	Requirement string
+	Err         error
+}
+
+func (e *ParamError) Error() string {
+	if e.Err == ErrMissingParam {
+		return fmt.Sprintf(`param "%s" is not provided`, e.Param)
+	}
+
+	return fmt.Sprintf(`invalid format provided for param "%s"`, e.Param)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+type PatternError struct {
+	Route   string
+	Pattern string
+	Param   string
+	Cause   error
+}
+
+func (e *PatternError) Error() string {
+	var message strings.Builder
+
+	message.WriteString("invalid pattern")
+	if e.Pattern != "" {
+		message.WriteString(fmt.Sprintf(` "%s"`, e.Pattern))
+	}
+	if e.Param != "" {
+		message.WriteString(fmt.Sprintf(` for param "%s"`, e.Param))
+	}
+	if e.Route != "" {
+		message.WriteString(fmt.Sprintf(` in route "%s"`, e.Route))
+	}
+	if e.Cause != nil {
+		message.WriteString(fmt.Sprintf(`: %v`, e.Cause))
+	}
+
+	return message.String()
+}
+
+func (e *PatternError) Is(target error) bool {
+	return target == ErrInvalidPattern
+}
+
+func (e *PatternError) Unwrap() error {
+	return e.Cause
+}
+
+type MethodNotAllowedError struct {
+	Allowed []string
+}
+
+func (e *MethodNotAllowedError) Error() string {
+	return fmt.Sprintf(`method not allowed, allowed methods: %s`, strings.Join(e.Allowed, ", "))
+}
+
+func (e *MethodNotAllowedError) Unwrap() error {
+	return ErrMethodNotAllowed
+}
+
+type MultiError []error
+
+func (e MultiError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e MultiError) Unwrap() []error {
+	return e
+}
+
+func (e MultiError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e MultiError) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type errorCollector struct {
+	collect bool
+	errors  MultiError
+}
+
+func (c *errorCollector) add(err error) bool {
+	c.errors = append(c.errors, err)
+	return c.collect
+}
+
+func (c *errorCollector) err() error {
+	if len(c.errors) == 0 {
+		return nil
+	}
+
+	if !c.collect {
+		return c.errors[0]
+	}
+
+	return c.errors
+}
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors_Registration(t *testing.T) {
+	r := New()
+
+	err := r.AddGetRoute("user", "/users/{id}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("user", "/other/{id}", nil)
+	var routeErr *RouteError
+	if !errors.Is(err, ErrDuplicateRoute) || !errors.As(err, &routeErr) || routeErr.Route != "user" {
+		t.Errorf(`expected duplicate route error for "user" but got %v`, err)
+	}
+
+	err = r.AddGetRoute("broken", "/users/{id:[0-9}", nil)
+	var patternErr *PatternError
+	if !errors.Is(err, ErrInvalidPattern) || !errors.As(err, &patternErr) || patternErr.Route != "broken" {
+		t.Errorf(`expected invalid pattern error for "broken" but got %v`, err)
+	}
+}
+
+func TestErrors_CollectRegistration(t *testing.T) {
+	r, err := NewBuilder().SetCollectErrors(true).Build()
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("broken", "/{id:[0-9}/{id}/{name:(}", nil)
+
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf(`expected multi error but got %v`, err)
+	}
+	if len(multiErr) != 3 {
+		t.Errorf(`expected 3 errors but got %d: %v`, len(multiErr), err)
+	}
+	if !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf(`expected invalid pattern error but got %v`, err)
+	}
+
+	var patternErr *PatternError
+	if !multiErr.Is(ErrInvalidPattern) || !multiErr.As(&patternErr) || patternErr.Route != "broken" {
+		t.Errorf(`expected multi error to match invalid pattern error but got %v`, err)
+	}
+	if multiErr.Is(ErrInvalidParam) {
+		t.Errorf(`not expected multi error to match %v`, ErrInvalidParam)
+	}
+}
+
+func TestErrors_URL(t *testing.T) {
+	cases := []struct {
+		collect bool
+		params  ParamsMap
+		errors  []*ParamError
+	}{
+		{
+			collect: false,
+			params:  ParamsMap{"id": "abc"},
+			errors: []*ParamError{
+				{Route: "user", Param: "id", Value: "abc", Requirement: "([0-9]+)", Err: ErrInvalidParam},
+			},
+		},
+		{
+			collect: true,
+			params:  ParamsMap{"id": "abc"},
+			errors: []*ParamError{
+				{Route: "user", Param: "id", Value: "abc", Requirement: "([0-9]+)", Err: ErrInvalidParam},
+				{Route: "user", Param: "slug", Err: ErrMissingParam},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		r, err := NewBuilder().SetCollectErrors(c.collect).Build()
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		err = r.AddGetRoute("user", "/users/{id:[0-9]+}/{slug}", nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		route, _ := r.FindRouteByName("user")
+		_, err = route.URL(c.params)
+
+		var result []error
+		if multiErr, ok := err.(MultiError); ok {
+			result = multiErr
+		} else if err != nil {
+			result = []error{err}
+		}
+
+		if len(result) != len(c.errors) {
+			t.Errorf(`expected %d errors but got %v`, len(c.errors), err)
+			continue
+		}
+
+		for index, expected := range c.errors {
+			var paramErr *ParamError
+			if !errors.As(result[index], &paramErr) || *paramErr != *expected {
+				t.Errorf(`expected error %+v but got %+v`, expected, result[index])
+			}
+			if !errors.Is(err, expected.Err) {
+				t.Errorf(`expected error to match %v`, expected.Err)
+			}
+		}
+	}
+}
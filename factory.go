@@ -1,41 +1,86 @@
 package router
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"regexp/syntax"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-const defaultParamMatcher = `\{([a-z]+[\:]{0,1}[^\}]*)\}`
+const defaultHostParamRequirement = `([^\.]+)`
 
 type factory struct {
-	paramMatcher *regexp.Regexp
-	requirement  *regexp.Regexp
+	requirement     *regexp.Regexp
+	hostRequirement *regexp.Regexp
+	basePath        basePath
+	collectErrors   bool
+	lock            *sync.RWMutex
+	root            *routeGroup
+	sequence        uint64
+	names           map[string]Route
+	versioned       map[string]*versionedRouteGroup
+	hosts           atomic.Value
+	anchored        sync.Map
 }
 
-func newFactory(requirement *regexp.Regexp) *factory {
-	paramMatcher := regexp.MustCompile(defaultParamMatcher)
+func newFactory(requirement *regexp.Regexp, basePath basePath, collectErrors bool) *factory {
+	hostRequirement := regexp.MustCompile(defaultHostParamRequirement)
 
 	return &factory{
-		paramMatcher: paramMatcher,
-		requirement:  requirement,
+		requirement:     requirement,
+		hostRequirement: hostRequirement,
+		basePath:        basePath,
+		collectErrors:   collectErrors,
+		lock:            &sync.RWMutex{},
+		names:           map[string]Route{},
+		versioned:       map[string]*versionedRouteGroup{},
 	}
 }
 
-func (f *factory) createRoute(name string, path string, method string, action Action, options Options) (*route, error) {
-	pairs, required, err := f.createParams(path)
+func (f *factory) createRoute(name string, path string, methods methodsList, action Action, options Options) (*route, error) {
+	collector := f.newErrorCollector()
+
+	segments, pairs, required, err := f.createParams(name, path, f.requirement, collector)
+	if err != nil {
+		return nil, err
+	}
+
+	hostSegments, hostPairs, hostRequired, err := f.createParams(name, options.Host, f.hostRequirement, collector)
 	if err != nil {
 		return nil, err
 	}
 
+	for key, value := range hostPairs {
+		if _, ok := pairs[key]; ok {
+			if !collector.add(&PatternError{Route: name, Pattern: options.Host, Param: key, Cause: errors.New("param is provided in both host and path")}) {
+				return nil, collector.err()
+			}
+			continue
+		}
+
+		pairs[key] = value
+	}
+
 	defaults := f.createDefaultParams(options.DefaultParams)
 
-	requirements, err := f.createParamsRequirements(name, pairs)
+	requirements, err := f.createParamsRequirements(name, pairs, collector)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := collector.err(); err != nil {
+		return nil, err
+	}
+
+	forward, err := f.createForwardRouteRegexp(name, path, segments, requirements)
 	if err != nil {
 		return nil, err
 	}
 
-	forward, err := f.createForwardRouteRegexp(name, path, required, requirements)
+	hostRegexp, err := f.createHostRegexp(name, options.Host, hostSegments, hostRequired, requirements)
 	if err != nil {
 		return nil, err
 	}
@@ -44,32 +89,53 @@ func (f *factory) createRoute(name string, path string, method string, action Ac
 		name:               name,
 		action:             action,
 		priority:           options.Priority,
-		method:             method,
+		methods:            methods,
 		secure:             options.Secure,
 		host:               options.Host,
+		hostRegexp:         hostRegexp,
+		hostParams:         hostRequired,
+		hostSegments:       hostSegments,
 		forwardRegexp:      forward,
-		reversePath:        path,
+		reversePath:        segments.String(),
+		template:           path,
+		segments:           segments,
+		urlSegments:        segments.withPrefix(f.basePath.value),
 		requiredParams:     required,
 		paramsRequirements: requirements,
+		anchored:           f.createAnchoredRequirements(requirements),
 		defaultParams:      defaults,
+		defaultParamsList:  defaults.toParams(),
 		requirement:        f.requirement,
+		anchoredDefault:    f.anchorRequirement(f.requirement),
+		basePath:           f.basePath,
+		collectErrors:      f.collectErrors,
+		metadata:           options.Metadata.Extend(nil),
+		tags:               newTagsList(options.Tags...),
+		deprecation:        options.Deprecation.copy(),
+		order:              newRouteOrder(segments, atomic.AddUint64(&f.sequence, 1)),
 	}, nil
 }
 
 func (f *factory) createRouteGroup(name string, path string, options Options) (*routeGroup, error) {
-	pairs, required, err := f.createParams(path)
+	collector := f.newErrorCollector()
+
+	segments, pairs, _, err := f.createParams(name, path, f.requirement, collector)
 	if err != nil {
 		return nil, err
 	}
 
 	defaults := f.createDefaultParams(options.DefaultParams)
 
-	requirements, err := f.createParamsRequirements(name, pairs)
+	requirements, err := f.createParamsRequirements(name, pairs, collector)
 	if err != nil {
 		return nil, err
 	}
 
-	forward, err := f.createForwardRouteGroupRegexp(name, path, required)
+	if err := collector.err(); err != nil {
+		return nil, err
+	}
+
+	forward, err := f.createForwardRouteGroupRegexp(name, path, segments, requirements)
 	if err != nil {
 		return nil, err
 	}
@@ -79,22 +145,121 @@ func (f *factory) createRouteGroup(name string, path string, options Options) (*
 		secure:             options.Secure,
 		host:               options.Host,
 		forwardRegexp:      forward,
-		reversePath:        path,
+		reversePath:        segments.String(),
 		originalPath:       path,
 		paramsRequirements: requirements,
 		defaultParams:      defaults,
 		routes:             []routeFinder{},
 		factory:            f,
+		basePath:           f.basePath,
+		lock:               f.lock,
+		metadata:           options.Metadata.Extend(nil),
+		tags:               newTagsList(options.Tags...),
+		deprecation:        options.Deprecation.copy(),
 	}, nil
 }
 
-func (f *factory) createParamsRequirements(name string, requirements map[string]string) (paramsRequirements, error) {
+func (f *factory) findRouteByName(name string) (Route, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	if route, ok := f.names[name]; ok {
+		return route, true
+	}
+
+	for index := strings.LastIndex(name, "."); index != -1; index = strings.LastIndex(name[:index], ".") {
+		if group, ok := f.versioned[name[:index+1]]; ok {
+			return group.findRouteByName(name)
+		}
+	}
+
+	return nil, false
+}
+
+func (f *factory) hasRouteName(name string) bool {
+	_, ok := f.names[name]
+	return ok
+}
+
+// update runs after every change of the route tree, while the write lock is
+// held, and rebuilds the state derived from it. Fallbacks are rebuilt only for
+// the versioned groups which contain the changed group.
+func (f *factory) update(changed *routeGroup) error {
+	for _, group := range f.versioned {
+		if !strings.HasPrefix(changed.getPrefix(), group.base.getPrefix()) {
+			continue
+		}
+
+		err := group.buildFallbacks()
+		if err != nil {
+			return err
+		}
+	}
+
+	f.hosts.Store((*hostTable)(nil))
+
+	return nil
+}
+
+func (f *factory) indexRoute(finder routeFinder) {
+	switch child := finder.(type) {
+	case *route:
+		f.names[child.name] = child
+	case *localizedRoute:
+		f.names[child.name] = child
+		for locale, route := range child.locales {
+			f.names[fmt.Sprintf("%s.%s", child.name, locale)] = route
+		}
+	case *routeGroup:
+		for _, r := range child.routes {
+			f.indexRoute(r)
+		}
+	case *versionedRouteGroup:
+		f.versioned[child.base.getPrefix()] = child
+		for _, r := range child.base.routes {
+			f.indexRoute(r)
+		}
+	}
+}
+
+func (f *factory) unindexRoute(finder routeFinder) {
+	switch child := finder.(type) {
+	case *route:
+		delete(f.names, child.name)
+	case *localizedRoute:
+		delete(f.names, child.name)
+		for locale := range child.locales {
+			delete(f.names, fmt.Sprintf("%s.%s", child.name, locale))
+		}
+	case *routeGroup:
+		for _, r := range child.routes {
+			f.unindexRoute(r)
+		}
+	case *versionedRouteGroup:
+		delete(f.versioned, child.base.getPrefix())
+		for _, r := range child.base.routes {
+			f.unindexRoute(r)
+		}
+	}
+}
+
+func (f *factory) newErrorCollector() *errorCollector {
+	return &errorCollector{
+		collect: f.collectErrors,
+	}
+}
+
+func (f *factory) createParamsRequirements(name string, requirements map[string]string, collector *errorCollector) (paramsRequirements, error) {
 	result := paramsRequirements{}
 
 	for key, value := range requirements {
 		compiled, err := regexp.Compile(value)
 		if err != nil {
-			return nil, fmt.Errorf(`error while compiling regexp for param "%s" in route "%s": %w`, key, name, err)
+			if !collector.add(&PatternError{Route: name, Pattern: value, Param: key, Cause: err}) {
+				return nil, collector.err()
+			}
+			continue
 		}
 
 		result[key] = compiled
@@ -103,46 +268,112 @@ func (f *factory) createParamsRequirements(name string, requirements map[string]
 	return result, nil
 }
 
-func (f *factory) createForwardRouteRegexp(name string, path string, required paramsList, requirements paramsRequirements) (*regexp.Regexp, error) {
-	forward := path
+// createAnchoredRequirements matches whole param values, so URL generation
+// checks them with MatchString. They are shared between routes with the same
+// requirement.
+func (f *factory) createAnchoredRequirements(requirements paramsRequirements) paramsRequirements {
+	if len(requirements) == 0 {
+		return nil
+	}
 
-	for _, key := range required {
-		compiled, ok := requirements[key]
-		if !ok {
-			compiled = f.requirement
+	result := make(paramsRequirements, len(requirements))
+
+	for key, requirement := range requirements {
+		if anchored := f.anchorRequirement(requirement); anchored != nil {
+			result[key] = anchored
 		}
+	}
 
-		wrapped := fmt.Sprintf("{%s}", requirements[key].String())
-		forward = strings.Replace(forward, wrapped, compiled.String(), 1)
+	return result
+}
+
+func (f *factory) anchorRequirement(requirement *regexp.Regexp) *regexp.Regexp {
+	if anchored, ok := f.anchored.Load(requirement.String()); ok {
+		return anchored.(*regexp.Regexp)
+	}
+
+	anchored, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", requirement.String()))
+	if err != nil {
+		return nil
 	}
 
-	forward = fmt.Sprintf("^%s$", forward)
+	f.anchored.Store(requirement.String(), anchored)
+
+	return anchored
+}
+
+func (f *factory) createForwardRouteRegexp(name string, path string, segments pathTemplate, requirements paramsRequirements) (*regexp.Regexp, error) {
+	forward := fmt.Sprintf("^%s$", f.createPattern(segments, requirements, f.requirement))
 	result, err := regexp.Compile(forward)
 	if err != nil {
-		return nil, fmt.Errorf(`error while compiling regexp for path "%s" in route "%s": %w`, path, name, err)
+		return nil, &PatternError{Route: name, Pattern: path, Cause: err}
 	}
 
 	return result, nil
 }
 
-func (f *factory) createForwardRouteGroupRegexp(name string, path string, required paramsList) (*regexp.Regexp, error) {
-	forward := path
-	defaults := f.requirement.String()
+func (f *factory) createForwardRouteGroupRegexp(name string, path string, segments pathTemplate, requirements paramsRequirements) (*regexp.Regexp, error) {
+	forward := fmt.Sprintf("^%s", f.createPattern(segments, requirements, f.requirement))
+	result, err := regexp.Compile(forward)
+	if err != nil {
+		return nil, &PatternError{Route: name, Pattern: path, Cause: err}
+	}
 
-	for _, key := range required {
-		wrapped := fmt.Sprintf("{%s}", key)
-		forward = strings.Replace(forward, wrapped, defaults, 1)
+	return result, nil
+}
+
+func (f *factory) createHostRegexp(name string, host string, segments pathTemplate, required paramsList, requirements paramsRequirements) (*regexp.Regexp, error) {
+	if len(required) == 0 {
+		return nil, nil
 	}
 
-	forward = fmt.Sprintf("^%s", forward)
+	forward := fmt.Sprintf("^%s$", f.createPattern(segments, requirements, f.hostRequirement))
 	result, err := regexp.Compile(forward)
 	if err != nil {
-		return nil, fmt.Errorf(`error while compiling regexp for path "%s" in route group "%s": %w`, path, name, err)
+		return nil, &PatternError{Route: name, Pattern: host, Cause: err}
+	}
+
+	return result, nil
+}
+
+func (f *factory) createMountRegexp(mounted *route) (*regexp.Regexp, error) {
+	prefix := strings.TrimSuffix(f.createPattern(mounted.segments, mounted.paramsRequirements, f.requirement), "/")
+
+	forward, err := regexp.Compile(fmt.Sprintf("^%s(?:/.*)?$", prefix))
+	if err != nil {
+		return nil, &PatternError{Route: mounted.name, Pattern: mounted.template, Cause: err}
+	}
+
+	result, err := regexp.Compile(fmt.Sprintf("^%s", prefix))
+	if err != nil {
+		return nil, &PatternError{Route: mounted.name, Pattern: mounted.template, Cause: err}
 	}
 
+	mounted.forwardRegexp = forward
+
 	return result, nil
 }
 
+func (f *factory) createPattern(segments pathTemplate, requirements paramsRequirements, requirement *regexp.Regexp) string {
+	var pattern strings.Builder
+
+	for _, segment := range segments {
+		if !segment.param {
+			pattern.WriteString(regexp.QuoteMeta(segment.value))
+			continue
+		}
+
+		compiled, ok := requirements[segment.value]
+		if !ok {
+			compiled = requirement
+		}
+
+		pattern.WriteString(compiled.String())
+	}
+
+	return pattern.String()
+}
+
 func (f *factory) createDefaultParams(defaults map[string]string) paramsValues {
 	if defaults == nil {
 		return paramsValues{}
@@ -150,34 +381,88 @@ func (f *factory) createDefaultParams(defaults map[string]string) paramsValues {
 	return defaults
 }
 
-func (f *factory) createParams(path string) (ParamsMap, paramsList, error) {
+func (f *factory) createParams(name string, path string, defaultRequirement *regexp.Regexp, collector *errorCollector) (pathTemplate, ParamsMap, paramsList, error) {
 	paramMap := ParamsMap{}
+	seen := map[string]bool{}
 	var list paramsList
 
-	params := f.paramMatcher.FindAllStringSubmatch(path, -1)
+	segments, err := parseTemplate(path)
+	if err != nil {
+		return nil, nil, nil, &PatternError{Route: name, Pattern: path, Cause: err}
+	}
 
-	for _, v := range params {
-		if len(v) != 2 {
-			return nil, nil, fmt.Errorf(`invlaid path provided: %s`, path)
+	for _, segment := range segments {
+		if !segment.param {
+			continue
 		}
 
-		matches := strings.Split(v[1], ":")
-		if len(matches) == 0 || matches[0] == "" {
-			return nil, nil, fmt.Errorf(`empty param is provided in path: %s`, path)
+		if seen[segment.value] {
+			if !collector.add(&PatternError{Route: name, Pattern: path, Param: segment.value, Cause: errors.New("param is provided multiple times")}) {
+				return nil, nil, nil, collector.err()
+			}
+			continue
 		}
+		seen[segment.value] = true
 
-		if _, ok := paramMap[matches[0]]; ok {
-			return nil, nil, fmt.Errorf(`param with name "%s" is provided mutiple times in path: %s`, matches[0], path)
+		requirement := defaultRequirement.String()
+		if segment.requirement != "" {
+			requirement = segment.requirement
 		}
 
-		requirement := f.requirement.String()
-		if len(matches) > 1 && matches[1] != "" {
-			requirement = fmt.Sprintf("(%s)", matches[1])
+		normalized, err := normalizeRequirement(requirement)
+		if err != nil {
+			if !collector.add(&PatternError{Route: name, Pattern: requirement, Param: segment.value, Cause: err}) {
+				return nil, nil, nil, collector.err()
+			}
+			continue
 		}
 
-		paramMap[matches[0]] = requirement
-		list = append(list, matches[0])
+		paramMap[segment.value] = normalized
+		list = append(list, segment.value)
+	}
+
+	return segments, paramMap, list, nil
+}
+
+func normalizeRequirement(requirement string) (string, error) {
+	parsed, err := syntax.Parse(requirement, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Op == syntax.OpCapture && parsed.Name == "" && !hasCaptures(parsed.Sub[0]) {
+		return requirement, nil
+	}
+
+	if !hasCaptures(parsed) {
+		return fmt.Sprintf("(%s)", requirement), nil
+	}
+
+	return fmt.Sprintf("(%s)", removeCaptures(parsed).String()), nil
+}
+
+func hasCaptures(parsed *syntax.Regexp) bool {
+	if parsed.Op == syntax.OpCapture {
+		return true
+	}
+
+	for _, sub := range parsed.Sub {
+		if hasCaptures(sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeCaptures(parsed *syntax.Regexp) *syntax.Regexp {
+	for index, sub := range parsed.Sub {
+		parsed.Sub[index] = removeCaptures(sub)
+	}
+
+	if parsed.Op == syntax.OpCapture {
+		return parsed.Sub[0]
 	}
 
-	return paramMap, list, nil
+	return parsed
 }
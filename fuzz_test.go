@@ -0,0 +1,133 @@
+//go:build go1.18
+// +build go1.18
+
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func FuzzRoute_URLExtractParams(f *testing.F) {
+	r := New()
+
+	err := r.AddGetRoute("file", "/users/{id:[0-9]+}/files/{slug:[a-z0-9-]+}/{name}/{path:.+}", nil)
+	if err != nil {
+		f.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByName("file")
+	if !ok {
+		f.Fatal(`expected route "file" to be found`)
+	}
+
+	f.Add("10", "report", "document.pdf", "a/b/c")
+	f.Add("0", "a-b", "%2F", "a b/?c#d")
+	f.Add("007", "x", "..", "//")
+
+	f.Fuzz(func(t *testing.T, id string, slug string, name string, path string) {
+		params := ParamsMap{
+			"id":   id,
+			"slug": slug,
+			"name": name,
+			"path": path,
+		}
+
+		result, err := route.URL(params)
+		if err != nil {
+			return
+		}
+
+		parsed, err := url.Parse(result.String())
+		if err != nil {
+			t.Fatalf(`not expected error for "%s" but got %v`, result, err)
+		}
+
+		extracted, err := route.ExtractParams(&http.Request{Method: http.MethodGet, URL: parsed})
+		if err != nil {
+			t.Fatalf(`not expected error for "%s" but got %v`, result, err)
+		}
+
+		if !reflect.DeepEqual(extracted, params) {
+			t.Errorf(`expected params %v for "%s" but got %v`, params, result, extracted)
+		}
+	})
+}
+
+func FuzzFactory_CreateParams(f *testing.F) {
+	f.Add("/users/{id}", "/users/10")
+	f.Add("/users/{id:[0-9]+}/{name:a|b}", "/users/10/b")
+	f.Add("/archive/{year:[0-9]{4}}/{month:(0[1-9]|1[0-2])}", "/archive/2020/12")
+	f.Add("/files/{path:.*}.{format:json|xml}", "/files/a/b.json")
+	f.Add("/a.b/{id:(?:x)|y}+", "/a.b/y+")
+	f.Add("/{id:[0-9}", "/1")
+	f.Add("/{id}/{id}", "/1/1")
+
+	f.Fuzz(func(t *testing.T, template string, path string) {
+		for _, collect := range []bool{false, true} {
+			factory := newFactory(regexp.MustCompile(DefaultParamRequirement), newBasePath("", false), collect)
+
+			_, _, _, _ = factory.createParams("fuzz", template, factory.requirement, factory.newErrorCollector())
+
+			route, err := factory.createRoute("fuzz", template, nil, nil, Options{})
+			if err != nil {
+				continue
+			}
+
+			if route.forwardRegexp.NumSubexp() != len(route.requiredParams) {
+				t.Fatalf(`expected %d groups for "%s" but got %d`, len(route.requiredParams), template, route.forwardRegexp.NumSubexp())
+			}
+
+			indexes := route.forwardRegexp.FindStringSubmatchIndex(path)
+			if indexes == nil {
+				continue
+			}
+
+			for index, param := range route.requiredParams {
+				requirement := route.paramsRequirements[param]
+				if requirement == nil {
+					t.Fatalf(`expected requirement for param "%s" in "%s"`, param, template)
+				}
+
+				if hasEmptyWidthAssertion(requirement.String()) {
+					continue
+				}
+
+				value := path[indexes[2*index+2]:indexes[2*index+3]]
+
+				declared := regexp.MustCompile("^(?:" + requirement.String() + ")$")
+				if !declared.MatchString(value) {
+					t.Errorf(`expected value "%s" of param "%s" in "%s" to match requirement "%s"`, value, param, template, requirement)
+				}
+			}
+		}
+	})
+}
+
+func hasEmptyWidthAssertion(requirement string) bool {
+	parsed, err := syntax.Parse(requirement, syntax.Perl)
+	if err != nil {
+		return true
+	}
+
+	return containsEmptyWidth(parsed)
+}
+
+func containsEmptyWidth(parsed *syntax.Regexp) bool {
+	switch parsed.Op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	}
+
+	for _, sub := range parsed.Sub {
+		if containsEmptyWidth(sub) {
+			return true
+		}
+	}
+
+	return false
+}
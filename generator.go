@@ -0,0 +1,159 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type ReferenceType int
+
+const (
+	AbsolutePath ReferenceType = iota
+	AbsoluteURL
+	NetworkPath
+	RelativePath
+)
+
+type URLGenerator interface {
+	Generate(name string, params ParamsMap, referenceType ReferenceType) (*url.URL, error)
+}
+
+type contextURLGenerator interface {
+	generateURL(params ParamsMap, referenceType ReferenceType, context urlContext) (*url.URL, error)
+}
+
+type urlContext struct {
+	scheme string
+	host   string
+	path   string
+}
+
+func newURLContext(request *http.Request) urlContext {
+	if request == nil {
+		return urlContext{}
+	}
+
+	scheme := "http"
+	if request.TLS != nil || (request.URL != nil && request.URL.Scheme == "https") {
+		scheme = "https"
+	}
+
+	path := "/"
+	if request.URL != nil && request.URL.Path != "" {
+		path = request.URL.EscapedPath()
+	}
+
+	return urlContext{
+		scheme: scheme,
+		host:   requestHost(request),
+		path:   path,
+	}
+}
+
+func (c urlContext) generate(referenceType ReferenceType, scheme string, host string, path string, rawPath string) (*url.URL, error) {
+	requiresHost := host != "" && host != c.host
+	requiresScheme := c.scheme != "" && scheme != c.scheme
+	if host == "" {
+		host = c.host
+	}
+
+	result := &url.URL{}
+
+	switch referenceType {
+	case AbsoluteURL:
+		if host == "" {
+			return nil, ErrHostNotProvided
+		}
+
+		result.Scheme = scheme
+		result.Host = host
+	case NetworkPath:
+		if host == "" {
+			return nil, ErrHostNotProvided
+		}
+
+		result.Host = host
+	case RelativePath:
+		if requiresHost || requiresScheme {
+			return c.generate(AbsoluteURL, scheme, host, path, rawPath)
+		}
+
+		rawPath = relativePath(c.path, rawPath)
+
+		unescaped, err := url.PathUnescape(rawPath)
+		if err != nil {
+			return nil, err
+		}
+
+		path = unescaped
+	default:
+		if requiresHost || requiresScheme {
+			return c.generate(AbsoluteURL, scheme, host, path, rawPath)
+		}
+	}
+
+	result.Path = path
+	if rawPath != path && rawPath != result.EscapedPath() {
+		result.RawPath = rawPath
+	}
+
+	return result, nil
+}
+
+func relativePath(basePath string, targetPath string) string {
+	if basePath == targetPath {
+		return ""
+	}
+
+	sourceDirs := strings.Split(strings.TrimPrefix(basePath, "/"), "/")
+	targetDirs := strings.Split(strings.TrimPrefix(targetPath, "/"), "/")
+
+	sourceDirs = sourceDirs[:len(sourceDirs)-1]
+	targetFile := targetDirs[len(targetDirs)-1]
+	targetDirs = targetDirs[:len(targetDirs)-1]
+
+	common := 0
+	for common < len(sourceDirs) && common < len(targetDirs) && sourceDirs[common] == targetDirs[common] {
+		common++
+	}
+
+	targetDirs = append(targetDirs[common:], targetFile)
+	result := strings.Repeat("../", len(sourceDirs)-common) + strings.Join(targetDirs, "/")
+
+	colon := strings.Index(result, ":")
+	slash := strings.Index(result, "/")
+	if result == "" || result[0] == '/' || (colon != -1 && (slash == -1 || colon < slash)) {
+		result = "./" + result
+	}
+
+	return result
+}
+
+type urlGenerator struct {
+	router  Router
+	context urlContext
+}
+
+var _ URLGenerator = &urlGenerator{}
+
+func NewURLGenerator(router Router, request *http.Request) URLGenerator {
+	return &urlGenerator{
+		router:  router,
+		context: newURLContext(request),
+	}
+}
+
+func (g *urlGenerator) Generate(name string, params ParamsMap, referenceType ReferenceType) (*url.URL, error) {
+	route, ok := g.router.FindRouteByName(name)
+	if !ok {
+		return nil, ErrRouteNotFound
+	}
+
+	generator, ok := route.(contextURLGenerator)
+	if !ok {
+		return route.GenerateURL(params, referenceType)
+	}
+
+	return generator.generateURL(params, referenceType, g.context)
+}
@@ -0,0 +1,136 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestURLGenerator_Generate(t *testing.T) {
+	r := New()
+
+	err := r.AddGetRoute("article", "/blog/{slug}/comments", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("admin", "/admin", http.MethodGet, nil, Options{
+		Secure: true,
+		Host:   "admin.domain.com",
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		request       string
+		name          string
+		params        ParamsMap
+		referenceType ReferenceType
+		result        string
+		err           error
+	}{
+		{
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: AbsolutePath,
+			result:        "/blog/hello/comments",
+		},
+		{
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: AbsoluteURL,
+			err:           ErrHostNotProvided,
+		},
+		{
+			request:       "http://domain.com:8080/blog/other/comments",
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: AbsoluteURL,
+			result:        "http://domain.com:8080/blog/hello/comments",
+		},
+		{
+			request:       "https://domain.com/blog/other/comments",
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: NetworkPath,
+			result:        "//domain.com/blog/hello/comments",
+		},
+		{
+			request:       "http://domain.com/blog/other/comments",
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: RelativePath,
+			result:        "../hello/comments",
+		},
+		{
+			request:       "http://domain.com/blog/hello/comments",
+			name:          "article",
+			params:        ParamsMap{"slug": "hello"},
+			referenceType: RelativePath,
+			result:        "",
+		},
+		{
+			request:       "http://domain.com/blog/other/comments",
+			name:          "admin",
+			referenceType: AbsolutePath,
+			result:        "https://admin.domain.com/admin",
+		},
+		{
+			request:       "https://admin.domain.com/blog/other/comments",
+			name:          "admin",
+			referenceType: AbsolutePath,
+			result:        "/admin",
+		},
+		{
+			request:       "http://admin.domain.com/blog/other/comments",
+			name:          "admin",
+			referenceType: RelativePath,
+			result:        "https://admin.domain.com/admin",
+		},
+		{
+			name: "missing",
+			err:  ErrRouteNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		var req *http.Request
+		if c.request != "" {
+			req, err = http.NewRequest(http.MethodGet, c.request, nil)
+			if err != nil {
+				t.Fatalf(`not expected error but got %v`, err)
+			}
+		}
+
+		result, err := NewURLGenerator(r, req).Generate(c.name, c.params, c.referenceType)
+		if err != c.err {
+			t.Errorf(`expected error %v but got %v`, c.err, err)
+		} else if err == nil && result.String() != c.result {
+			t.Errorf(`expected url "%s" but got "%s"`, c.result, result.String())
+		}
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	cases := []struct {
+		basePath   string
+		targetPath string
+		result     string
+	}{
+		{basePath: "/a/b/", targetPath: "/a/b/c/d", result: "c/d"},
+		{basePath: "/a/b/c/", targetPath: "/a/b/c/d", result: "d"},
+		{basePath: "/a/b/c/d", targetPath: "/a/b/c/d", result: ""},
+		{basePath: "/a/b/c/d", targetPath: "/a/b/", result: "../"},
+		{basePath: "/a/b/c/d", targetPath: "/a/b/c/", result: "./"},
+		{basePath: "/a/b/c/d", targetPath: "/", result: "../../../"},
+		{basePath: "/a/b/c/d", targetPath: "/a/b/c/other:colon", result: "./other:colon"},
+		{basePath: "/", targetPath: "/a/b/c/d", result: "a/b/c/d"},
+	}
+
+	for _, c := range cases {
+		result := relativePath(c.basePath, c.targetPath)
+		if result != c.result {
+			t.Errorf(`expected relative path "%s" from "%s" to "%s" but got "%s"`, c.result, c.basePath, c.targetPath, result)
+		}
+	}
+}
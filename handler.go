@@ -0,0 +1,101 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type HandlerOptions struct {
+	NotFound         http.Handler
+	MethodNotAllowed http.Handler
+	DeprecationHook  DeprecationHook
+}
+
+type handler struct {
+	router  Router
+	options HandlerOptions
+}
+
+type matchContextKey struct{}
+
+var _ http.Handler = &handler{}
+
+func NewHandler(router Router, options HandlerOptions) http.Handler {
+	return &handler{
+		router:  router,
+		options: options,
+	}
+}
+
+func MatchFromContext(ctx context.Context) (Match, bool) {
+	match, ok := ctx.Value(matchContextKey{}).(Match)
+	return match, ok
+}
+
+func (h *handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	match, err := h.router.Match(request)
+
+	var methodErr *MethodNotAllowedError
+	if errors.As(err, &methodErr) {
+		writer.Header().Set("Allow", strings.Join(methodErr.Allowed, ", "))
+		h.serveError(writer, request, h.options.MethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		h.serveError(writer, request, h.options.NotFound, http.StatusNotFound)
+		return
+	}
+
+	action, ok := h.getActionHandler(match.Route.Action())
+	if !ok {
+		h.serveError(writer, request, h.options.NotFound, http.StatusNotFound)
+		return
+	}
+
+	if deprecation, ok := match.Route.Deprecation(); ok {
+		deprecation.writeHeaders(writer.Header(), h.getSuccessorURL(deprecation, match, request))
+
+		if h.options.DeprecationHook != nil {
+			h.options.DeprecationHook(match.Route, request)
+		}
+	}
+
+	ctx := context.WithValue(request.Context(), matchContextKey{}, match)
+	action.ServeHTTP(writer, request.WithContext(ctx))
+}
+
+func (h *handler) getActionHandler(action Action) (http.Handler, bool) {
+	switch value := action.(type) {
+	case http.Handler:
+		return value, true
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(value), true
+	}
+
+	return nil, false
+}
+
+func (h *handler) getSuccessorURL(deprecation Deprecation, match Match, request *http.Request) string {
+	if deprecation.Successor == "" {
+		return ""
+	}
+
+	result, err := NewURLGenerator(h.router, request).Generate(deprecation.Successor, match.Params(), AbsolutePath)
+	if err != nil {
+		return ""
+	}
+
+	return result.String()
+}
+
+func (h *handler) serveError(writer http.ResponseWriter, request *http.Request, fallback http.Handler, status int) {
+	if fallback != nil {
+		fallback.ServeHTTP(writer, request)
+		return
+	}
+
+	http.Error(writer, http.StatusText(status), status)
+}
@@ -0,0 +1,109 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	r := New()
+
+	action := func(writer http.ResponseWriter, request *http.Request) {
+		match, _ := MatchFromContext(request.Context())
+		_, _ = writer.Write([]byte(match.Route.Name() + ":" + match.PathParams["id"]))
+	}
+
+	err := r.AddGetRoute("v2.user", "/v2/users/{id}", action)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	group, err := r.AddRouteGroup("v1", "/v1", Options{
+		Deprecation: &Deprecation{
+			Since:     time.Unix(1600000000, 0),
+			Sunset:    sunset,
+			Successor: "v2.user",
+		},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddGetRoute("user", "/users/{id}", action)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	var deprecated []string
+	h := NewHandler(r, HandlerOptions{
+		DeprecationHook: func(route Route, request *http.Request) {
+			deprecated = append(deprecated, route.Name())
+		},
+	})
+
+	cases := []struct {
+		method  string
+		url     string
+		status  int
+		body    string
+		headers map[string]string
+	}{
+		{
+			method: http.MethodGet,
+			url:    "/v2/users/10",
+			status: http.StatusOK,
+			body:   "v2.user:10",
+			headers: map[string]string{
+				"Deprecation": "",
+			},
+		},
+		{
+			method: http.MethodGet,
+			url:    "/v1/users/10",
+			status: http.StatusOK,
+			body:   "v1.user:10",
+			headers: map[string]string{
+				"Deprecation": "@1600000000",
+				"Sunset":      "Tue, 01 Jan 2030 00:00:00 GMT",
+				"Link":        `</v2/users/10>; rel="successor-version"`,
+			},
+		},
+		{
+			method: http.MethodPost,
+			url:    "/v2/users/10",
+			status: http.StatusMethodNotAllowed,
+			headers: map[string]string{
+				"Allow": http.MethodGet,
+			},
+		},
+		{
+			method: http.MethodGet,
+			url:    "/v3/users/10",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, httptest.NewRequest(c.method, c.url, nil))
+
+		if recorder.Code != c.status {
+			t.Errorf(`expected status %d for "%s" but got %d`, c.status, c.url, recorder.Code)
+		}
+		if c.body != "" && recorder.Body.String() != c.body {
+			t.Errorf(`expected body "%s" but got "%s"`, c.body, recorder.Body.String())
+		}
+		for key, value := range c.headers {
+			if recorder.Header().Get(key) != value {
+				t.Errorf(`expected header %s "%s" but got "%s"`, key, value, recorder.Header().Get(key))
+			}
+		}
+	}
+
+	if len(deprecated) != 1 || deprecated[0] != "v1.user" {
+		t.Errorf(`expected deprecation hook for "v1.user" but got %v`, deprecated)
+	}
+}
@@ -0,0 +1,201 @@
+package router
+
+import (
+	"net/http"
+	"regexp"
+)
+
+type hostKey struct {
+	host    string
+	pattern bool
+}
+
+type hostPartition struct {
+	regexp *regexp.Regexp
+	group  *routeGroup
+}
+
+// hostTable splits the route tree by host, so a request only walks routes
+// registered for its exact host, routes with a matching host pattern and
+// routes without a host.
+type hostTable struct {
+	exact    map[string]*routeGroup
+	patterns []hostPartition
+	fallback *routeGroup
+}
+
+func newHostTable(root *routeGroup) *hostTable {
+	regexps := map[string]*regexp.Regexp{}
+	partitions, keys := root.partitionByHost(regexps)
+
+	table := &hostTable{
+		exact: map[string]*routeGroup{},
+	}
+
+	for _, key := range keys {
+		switch {
+		case key.host == "":
+			table.fallback = partitions[key]
+		case key.pattern:
+			table.patterns = append(table.patterns, hostPartition{
+				regexp: regexps[key.host],
+				group:  partitions[key],
+			})
+		default:
+			table.exact[key.host] = partitions[key]
+		}
+	}
+
+	return table
+}
+
+// getHostTable builds the table on the first lookup after a change. It runs
+// under the read lock, so concurrent lookups may build equal tables, but
+// they never wait for each other.
+func (f *factory) getHostTable() *hostTable {
+	table, _ := f.hosts.Load().(*hostTable)
+	if table != nil {
+		return table
+	}
+
+	table = newHostTable(f.root)
+	f.hosts.Store(table)
+
+	return table
+}
+
+func (t *hostTable) findRouteByRequest(request *http.Request) (Route, bool) {
+	match, ok := t.matchRequest(request)
+	if !ok {
+		return nil, false
+	}
+
+	return match.getRoute(), true
+}
+
+// matchRequest uses the partitions only to skip routes of other hosts, so
+// the winner is still decided by the route order across all of them.
+func (t *hostTable) matchRequest(request *http.Request) (routeMatch, bool) {
+	if request == nil || request.URL == nil {
+		return routeMatch{}, false
+	}
+
+	host := requestHost(request)
+
+	var result routeMatch
+	found := false
+
+	add := func(group *routeGroup) {
+		match, ok := group.matchRequest(request)
+		if !ok {
+			return
+		}
+
+		if !found || match.route.precedes(result.route) {
+			result = match
+			found = true
+		}
+	}
+
+	if group, ok := t.exact[host]; ok {
+		add(group)
+	}
+
+	for _, partition := range t.patterns {
+		if partition.regexp.MatchString(host) {
+			add(partition.group)
+		}
+	}
+
+	if t.fallback != nil {
+		add(t.fallback)
+	}
+
+	return result, found
+}
+
+func (t *hostTable) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	if request == nil || request.URL == nil {
+		return methods
+	}
+
+	host := requestHost(request)
+
+	if group, ok := t.exact[host]; ok {
+		methods = group.allowedMethods(request, methods)
+	}
+
+	for _, partition := range t.patterns {
+		if partition.regexp.MatchString(host) {
+			methods = partition.group.allowedMethods(request, methods)
+		}
+	}
+
+	if t.fallback != nil {
+		methods = t.fallback.allowedMethods(request, methods)
+	}
+
+	return methods
+}
+
+func (g *routeGroup) partitionByHost(regexps map[string]*regexp.Regexp) (map[hostKey]*routeGroup, []hostKey) {
+	partitions := map[hostKey]*routeGroup{}
+	var keys []hostKey
+
+	add := func(key hostKey, finder routeFinder) {
+		partition, ok := partitions[key]
+		if !ok {
+			shadow := *g
+			shadow.routes = nil
+			shadow.hostMatched = key.host != ""
+			partition = &shadow
+
+			partitions[key] = partition
+			keys = append(keys, key)
+		}
+
+		partition.routes = append(partition.routes, finder)
+	}
+
+	for _, r := range g.routes {
+		child, ok := r.(*routeGroup)
+		if !ok {
+			add(getHostKey(r, regexps), r)
+			continue
+		}
+
+		childPartitions, childKeys := child.partitionByHost(regexps)
+		for _, key := range childKeys {
+			add(key, childPartitions[key])
+		}
+	}
+
+	return partitions, keys
+}
+
+func getHostKey(finder routeFinder, regexps map[string]*regexp.Regexp) hostKey {
+	var r *route
+
+	switch child := finder.(type) {
+	case *route:
+		r = child
+	case *localizedRoute:
+		r, _ = child.Route.(*route)
+	case *routeAlias:
+		r = child.route
+	}
+
+	if r == nil || r.host == "" {
+		return hostKey{}
+	}
+
+	if r.hostRegexp == nil {
+		return hostKey{host: r.host}
+	}
+
+	if _, ok := regexps[r.host]; !ok {
+		regexps[r.host] = r.hostRegexp
+	}
+
+	return hostKey{host: r.host, pattern: true}
+}
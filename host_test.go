@@ -0,0 +1,108 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_MatchHost(t *testing.T) {
+	r := New()
+
+	api, err := r.AddRouteGroup("api", "/api", Options{
+		Host: "api.domain.com",
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("shop", "/", http.MethodGet, nil, Options{Host: "shop.domain.com"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("tenant", "/", http.MethodGet, nil, Options{Host: "{tenant}.domain.com"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("tenantSettings", "/settings", http.MethodPost, nil, Options{Host: "{tenant}.domain.com"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("home", "/", http.MethodGet, nil, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("exact", "/{path:.*}", http.MethodGet, nil, Options{Host: "a.com", Priority: -10})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("login", "/login", http.MethodGet, nil, Options{Priority: 100})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddGetRoute("users", "/users", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		method string
+		url    string
+		name   string
+		err    error
+	}{
+		{method: http.MethodGet, url: "http://shop.domain.com/", name: "shop"},
+		{method: http.MethodGet, url: "http://acme.domain.com/", name: "tenant"},
+		{method: http.MethodGet, url: "http://other.com/", name: "home"},
+		{method: http.MethodGet, url: "http://api.domain.com/api/users", name: "api.users"},
+		{method: http.MethodGet, url: "http://api.domain.com/", name: "tenant"},
+		{method: http.MethodGet, url: "http://a.com/login", name: "login"},
+		{method: http.MethodGet, url: "http://a.com/profile", name: "exact"},
+		{method: http.MethodGet, url: "http://shop.domain.com/api/users", err: ErrRouteNotFound},
+		{method: http.MethodGet, url: "http://acme.domain.com/settings", err: ErrMethodNotAllowed},
+		{method: http.MethodGet, url: "http://other.com/settings", err: ErrRouteNotFound},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.url, nil)
+
+		match, err := r.Match(req)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf(`expected error %v for "%s" but got %v`, c.err, c.url, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" for "%s" but got "%s"`, c.name, c.url, match.Route.Name())
+		}
+
+		route, ok := r.FindRouteByRequest(req)
+		if !ok || route.Name() != c.name {
+			t.Errorf(`expected route "%s" for "%s" but got %v`, c.name, c.url, route)
+		}
+	}
+
+	err = r.RemoveRoute("shop")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByRequest(httptest.NewRequest(http.MethodGet, "http://shop.domain.com/", nil))
+	if !ok || route.Name() != "tenant" {
+		t.Errorf(`expected route "tenant" after removing "shop" but got %v`, route)
+	}
+}
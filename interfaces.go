@@ -1,12 +1,14 @@
 package router
 
 import (
+	"io/fs"
 	"net/http"
 	"net/url"
 )
 
 const (
 	DefaultParamRequirement = `([^\/]+)`
+	FragmentParam           = "_fragment"
 )
 
 type Action interface{}
@@ -16,19 +18,34 @@ type Options struct {
 	Secure        bool
 	Host          string
 	DefaultParams ParamsMap
+	Metadata      Metadata
+	Tags          []string
+	Deprecation   *Deprecation
 }
 
 type Route interface {
 	Priority() int
 	Name() string
 	Path() string
+	Template() string
+	Methods() []string
 	Action() Action
+	DefaultParams() ParamsMap
+	Metadata() Metadata
+	Tags() []string
+	HasTag(tag string) bool
+	Deprecation() (Deprecation, bool)
 	URL(params ParamsMap) (*url.URL, error)
+	UncheckedURL(params ParamsMap) (*url.URL, error)
+	GenerateURL(params ParamsMap, referenceType ReferenceType) (*url.URL, error)
 	ExtractParams(request *http.Request) (ParamsMap, error)
+	ExtractPathParams(request *http.Request) (ParamsMap, error)
 }
 
 type RouteGroup interface {
 	AddRoute(name string, path string, method string, action Action, options Options) error
+	AddMethodsRoute(name string, path string, methods []string, action Action, options Options) error
+	AddLocalizedRoute(name string, paths map[string]string, methods []string, action Action, options LocalizedOptions) error
 	AddDeleteRoute(name string, path string, action Action) error
 	AddGetRoute(name string, path string, action Action) error
 	AddHeadRoute(name string, path string, action Action) error
@@ -37,18 +54,41 @@ type RouteGroup interface {
 	AddPostRoute(name string, path string, action Action) error
 	AddPutRoute(name string, path string, action Action) error
 	AddRouteGroup(name string, path string, options Options) (RouteGroup, error)
+	AddVersionedRouteGroup(name string, path string, options VersionOptions) (VersionedRouteGroup, error)
+	AddStaticRoute(name string, path string, fileSystem http.FileSystem, options StaticOptions) error
+	AddStaticFSRoute(name string, path string, fileSystem fs.FS, options StaticOptions) error
+	AddRedirectRoute(name string, fromPath string, toRouteName string, status int) error
+	AddRouteAlias(routeName string, path string) error
+	Mount(name string, prefix string, handler http.Handler, options MountOptions) error
+	MountRouter(name string, prefix string, mounted Router, options Options) error
+	ReplaceRoute(name string, path string, method string, action Action, options Options) error
+	ReplaceMethodsRoute(name string, path string, methods []string, action Action, options Options) error
+	RemoveRoute(name string) error
+	RemoveRouteGroup(name string) error
+}
+
+type VersionedRouteGroup interface {
+	AddVersion(version string, options Options) (RouteGroup, error)
+	Versions() []string
 }
 
 type Router interface {
 	RouteGroup
 	FindRouteByRequest(request *http.Request) (Route, bool)
 	FindRouteByName(name string) (Route, bool)
+	Match(request *http.Request) (Match, error)
+	MatchParams(request *http.Request, params *Params) (Route, error)
+	Routes() []Route
+	OrderedRoutes() []Route
 }
 
 type Builder interface {
 	SetSecure(secure bool) Builder
 	SetHost(host string) Builder
 	SetDefaultParamRequirement(expr string) Builder
+	SetBasePath(path string) Builder
+	SetStripBasePath(strip bool) Builder
+	SetCollectErrors(collect bool) Builder
 	Build() (Router, error)
 }
 
@@ -0,0 +1,305 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const LocaleParam = "locale"
+
+type LocalizedOptions struct {
+	Options
+	DefaultLocale string
+}
+
+type localizedRoute struct {
+	Route
+	name          string
+	locales       map[string]*route
+	localesList   []string
+	defaultLocale string
+}
+
+var _ Route = &localizedRoute{}
+var _ routeFinder = &localizedRoute{}
+
+func (g *routeGroup) AddLocalizedRoute(name string, paths map[string]string, methods []string, action Action, options LocalizedOptions) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.addLocalizedRoute(name, paths, methods, action, options)
+}
+
+func (g *routeGroup) addLocalizedRoute(name string, paths map[string]string, methods []string, action Action, options LocalizedOptions) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	if len(paths) == 0 {
+		return &RouteError{Route: finalName, Err: fmt.Errorf(`no localized paths are provided`)}
+	}
+
+	localesList := make([]string, 0, len(paths))
+	for locale := range paths {
+		if locale == "" || strings.ContainsAny(locale, "./{}") {
+			return &RouteError{Route: finalName, Err: fmt.Errorf(`invalid locale "%s"`, locale)}
+		}
+
+		if g.factory.hasRouteName(fmt.Sprintf("%s.%s", finalName, locale)) {
+			return &RouteError{Route: fmt.Sprintf("%s.%s", finalName, locale), Err: ErrDuplicateRoute}
+		}
+
+		localesList = append(localesList, locale)
+	}
+	sort.Strings(localesList)
+
+	defaultLocale := options.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = options.DefaultParams[LocaleParam]
+	}
+	if defaultLocale == "" {
+		defaultLocale = localesList[0]
+	}
+
+	if _, ok := paths[defaultLocale]; !ok {
+		return &RouteError{Route: finalName, Err: fmt.Errorf(`path for default locale "%s" is not provided`, defaultLocale)}
+	}
+
+	locales := make(map[string]*route, len(paths))
+	localized := &localizedRoute{
+		name:          finalName,
+		locales:       locales,
+		localesList:   localesList,
+		defaultLocale: defaultLocale,
+	}
+
+	for _, locale := range localesList {
+		route, err := g.createRoute(fmt.Sprintf("%s.%s", finalName, locale), paths[locale], methods, action, localized.localeOptions(options.Options, locale))
+		if err != nil {
+			return err
+		}
+
+		localized.setLocale(locale, route)
+	}
+
+	return g.appendRoute(localized)
+}
+
+func (l *localizedRoute) Name() string {
+	return l.name
+}
+
+func (l *localizedRoute) URL(params ParamsMap) (*url.URL, error) {
+	return l.GenerateURL(params, AbsolutePath)
+}
+
+func (l *localizedRoute) UncheckedURL(params ParamsMap) (*url.URL, error) {
+	route, err := l.findLocaleRouteByParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return route.UncheckedURL(params)
+}
+
+func (l *localizedRoute) GenerateURL(params ParamsMap, referenceType ReferenceType) (*url.URL, error) {
+	return l.generateURL(params, referenceType, urlContext{})
+}
+
+func (l *localizedRoute) generateURL(params ParamsMap, referenceType ReferenceType, context urlContext) (*url.URL, error) {
+	route, err := l.findLocaleRouteByParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return route.generateURL(params, referenceType, context)
+}
+
+func (l *localizedRoute) findLocaleRouteByParams(params ParamsMap) (*route, error) {
+	locale := params[LocaleParam]
+	if locale == "" {
+		locale = l.defaultLocale
+	}
+
+	route, ok := l.locales[locale]
+	if !ok {
+		return nil, &ParamError{
+			Route: l.name,
+			Param: LocaleParam,
+			Value: locale,
+			Err:   ErrInvalidParam,
+		}
+	}
+
+	return route, nil
+}
+
+func (l *localizedRoute) ExtractParams(request *http.Request) (ParamsMap, error) {
+	route, err := l.findLocaleRoute(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return route.ExtractParams(request)
+}
+
+func (l *localizedRoute) ExtractPathParams(request *http.Request) (ParamsMap, error) {
+	route, err := l.findLocaleRoute(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return route.ExtractPathParams(request)
+}
+
+func (l *localizedRoute) findLocaleRoute(request *http.Request) (*route, error) {
+	if request == nil || request.URL == nil {
+		return nil, ErrURLNotProvided
+	}
+
+	for _, locale := range l.localesList {
+		route := l.locales[locale]
+
+		path, ok := route.basePath.strip(request.URL.Path)
+		if ok && route.pathMatches(path) {
+			return route, nil
+		}
+	}
+
+	return nil, ErrURLMismatch
+}
+
+func (l *localizedRoute) findRouteByRequest(request *http.Request) (Route, bool) {
+	match, ok := l.matchRequest(request)
+	if !ok {
+		return nil, false
+	}
+
+	return match.getRoute(), true
+}
+
+func (l *localizedRoute) findRouteByName(name string) (Route, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	if name == l.name {
+		return l, true
+	}
+
+	if !strings.HasPrefix(name, l.name+".") {
+		return nil, false
+	}
+
+	route, ok := l.locales[strings.TrimPrefix(name, l.name+".")]
+	if !ok {
+		return nil, false
+	}
+
+	return route, true
+}
+
+func (l *localizedRoute) matchRequest(request *http.Request) (routeMatch, bool) {
+	for _, locale := range l.localesList {
+		match, ok := l.locales[locale].matchRequest(request)
+		if !ok {
+			continue
+		}
+
+		match.result = l
+
+		return match, true
+	}
+
+	return routeMatch{}, false
+}
+
+func (l *localizedRoute) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	for _, locale := range l.localesList {
+		methods = l.locales[locale].allowedMethods(request, methods)
+	}
+
+	return methods
+}
+
+func (l *localizedRoute) collectRoutes(routes []*route) []*route {
+	for _, locale := range l.localesList {
+		routes = append(routes, l.locales[locale])
+	}
+
+	return routes
+}
+
+func (l *localizedRoute) findLocale(name string) (string, bool) {
+	if !strings.HasPrefix(name, l.name+".") {
+		return "", false
+	}
+
+	locale := strings.TrimPrefix(name, l.name+".")
+	_, ok := l.locales[locale]
+
+	return locale, ok
+}
+
+func (l *localizedRoute) localeOptions(options Options, locale string) Options {
+	options.DefaultParams = options.DefaultParams.Extend(ParamsMap{
+		LocaleParam: locale,
+	})
+
+	return options
+}
+
+func (l *localizedRoute) setLocale(locale string, route *route) {
+	l.locales[locale] = route
+	if locale == l.defaultLocale {
+		l.Route = route
+	}
+}
+
+// withLocale and withoutLocale return changed copies, as the route may still
+// be used by callers that found it before the change.
+func (l *localizedRoute) withLocale(locale string, route *route) *localizedRoute {
+	result := l.copy()
+	result.setLocale(locale, route)
+
+	return result
+}
+
+func (l *localizedRoute) withoutLocale(locale string) *localizedRoute {
+	result := l.copy()
+	delete(result.locales, locale)
+
+	result.localesList = make([]string, 0, len(result.locales))
+	for _, value := range l.localesList {
+		if value != locale {
+			result.localesList = append(result.localesList, value)
+		}
+	}
+
+	if locale == result.defaultLocale {
+		result.defaultLocale = result.localesList[0]
+		result.Route = result.locales[result.defaultLocale]
+	}
+
+	return result
+}
+
+func (l *localizedRoute) copy() *localizedRoute {
+	locales := make(map[string]*route, len(l.locales))
+	for locale, route := range l.locales {
+		locales[locale] = route
+	}
+
+	return &localizedRoute{
+		Route:         l.Route,
+		name:          l.name,
+		locales:       locales,
+		localesList:   l.localesList,
+		defaultLocale: l.defaultLocale,
+	}
+}
@@ -0,0 +1,298 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRouteGroup_AddLocalizedRoute(t *testing.T) {
+	cases := []struct {
+		url    string
+		found  bool
+		params ParamsMap
+	}{
+		{url: "/en/products/shoe", found: true, params: ParamsMap{"locale": "en", "slug": "shoe"}},
+		{url: "/de/produkte/schuh", found: true, params: ParamsMap{"locale": "de", "slug": "schuh"}},
+		{url: "/de/products/shoe", found: false},
+		{url: "/fr/produits/chaussure", found: false},
+	}
+
+	r := New()
+
+	err := r.AddLocalizedRoute("product", map[string]string{
+		"en": "/en/products/{slug}",
+		"de": "/de/produkte/{slug}",
+	}, []string{http.MethodGet}, "product", LocalizedOptions{DefaultLocale: "en"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+
+		match, err := r.Match(req)
+		if !c.found {
+			if err == nil {
+				t.Errorf(`expected "%s" not to be matched`, c.url)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		if match.Route.Name() != "product" {
+			t.Errorf(`expected route "product" but got "%s"`, match.Route.Name())
+		}
+
+		params, err := match.Route.ExtractParams(req)
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		}
+
+		if !reflect.DeepEqual(params, c.params) {
+			t.Errorf(`expected params %v but got %v`, c.params, params)
+		}
+	}
+}
+
+func TestLocalizedRoute_URL(t *testing.T) {
+	cases := []struct {
+		name          string
+		defaultLocale string
+		params        ParamsMap
+		url           string
+		err           error
+	}{
+		{name: "product", params: ParamsMap{"slug": "shoe"}, url: "/de/produkte/shoe"},
+		{name: "product", defaultLocale: "en", params: ParamsMap{"slug": "shoe"}, url: "/en/products/shoe"},
+		{name: "product", params: ParamsMap{"slug": "shoe", "locale": "en"}, url: "/en/products/shoe"},
+		{name: "product", params: ParamsMap{"slug": "shoe", "locale": "fr"}, err: ErrInvalidParam},
+		{name: "product", params: ParamsMap{}, err: ErrMissingParam},
+		{name: "product.en", params: ParamsMap{"slug": "shoe", "locale": "de"}, url: "/en/products/shoe"},
+	}
+
+	for _, c := range cases {
+		r := New()
+
+		err := r.AddLocalizedRoute("product", map[string]string{
+			"en": "/en/products/{slug}",
+			"de": "/de/produkte/{slug}",
+		}, []string{http.MethodGet}, "product", LocalizedOptions{DefaultLocale: c.defaultLocale})
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		route, ok := r.FindRouteByName(c.name)
+		if !ok {
+			t.Errorf(`expected route "%s" to be found`, c.name)
+			continue
+		}
+
+		result, err := route.URL(c.params)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf(`expected error %v but got %v`, c.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+			continue
+		}
+
+		if result.String() != c.url {
+			t.Errorf(`expected url "%s" but got "%s"`, c.url, result.String())
+		}
+	}
+}
+
+func TestRouteGroup_AddLocalizedRouteErrors(t *testing.T) {
+	cases := []struct {
+		paths   map[string]string
+		options LocalizedOptions
+	}{
+		{paths: map[string]string{}},
+		{paths: map[string]string{"en.us": "/en"}},
+		{paths: map[string]string{"en": "/en"}, options: LocalizedOptions{DefaultLocale: "de"}},
+		{paths: map[string]string{"en": "/en/{slug"}},
+	}
+
+	for _, c := range cases {
+		r := New()
+
+		err := r.AddLocalizedRoute("product", c.paths, []string{http.MethodGet}, nil, c.options)
+		if err == nil {
+			t.Errorf(`expected error for %v but got nil`, c.paths)
+		}
+	}
+
+	r := New()
+
+	err := r.AddLocalizedRoute("product", map[string]string{
+		"en": "/en/products/{slug}",
+		"de": "/de/produkte/{slug}",
+	}, []string{http.MethodGet}, "product", LocalizedOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("product", "/product", nil)
+	if !errors.Is(err, ErrDuplicateRoute) {
+		t.Errorf(`expected error %v but got %v`, ErrDuplicateRoute, err)
+	}
+
+	if len(r.Routes()) != 2 {
+		t.Errorf(`expected 2 routes but got %d`, len(r.Routes()))
+	}
+
+	err = r.RemoveRoute("product")
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+
+	if len(r.Routes()) != 0 {
+		t.Errorf(`expected no routes but got %d`, len(r.Routes()))
+	}
+}
+
+func TestLocalizedRoute_ReplaceRemoveLocale(t *testing.T) {
+	r := New()
+
+	err := r.AddLocalizedRoute("product", map[string]string{
+		"en": "/en/products/{slug}",
+		"de": "/de/produkte/{slug}",
+		"fr": "/fr/produits/{slug}",
+	}, []string{http.MethodGet}, "product", LocalizedOptions{DefaultLocale: "de"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.ReplaceRoute("product.de", "/de/artikel/{slug}", http.MethodGet, "replaced", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	match, err := r.Match(httptest.NewRequest(http.MethodGet, "/de/artikel/schuh", nil))
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+	if match.Route.Name() != "product" || !reflect.DeepEqual(match.Params(), ParamsMap{"locale": "de", "slug": "schuh"}) {
+		t.Errorf(`expected localized route with params but got "%s" and %v`, match.Route.Name(), match.Params())
+	}
+
+	route, _ := r.FindRouteByName("product")
+	result, err := route.URL(ParamsMap{"slug": "schuh"})
+	if err != nil || result.String() != "/de/artikel/schuh" {
+		t.Errorf(`expected url "/de/artikel/schuh" but got %v with %v`, result, err)
+	}
+
+	err = r.RemoveRoute("product.de")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, ok := r.FindRouteByName("product.de"); ok {
+		t.Error(`expected route "product.de" to be removed`)
+	}
+
+	_, err = r.Match(httptest.NewRequest(http.MethodGet, "/de/artikel/schuh", nil))
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf(`expected error %v but got %v`, ErrRouteNotFound, err)
+	}
+
+	result, err = route.URL(ParamsMap{"slug": "shoe"})
+	if err != nil || result.String() != "/de/artikel/shoe" {
+		t.Errorf(`expected previously found route to keep url "/de/artikel/shoe" but got %v with %v`, result, err)
+	}
+
+	route, _ = r.FindRouteByName("product")
+	result, err = route.URL(ParamsMap{"slug": "shoe"})
+	if err != nil || result.String() != "/en/products/shoe" {
+		t.Errorf(`expected url for new default locale "/en/products/shoe" but got %v with %v`, result, err)
+	}
+
+	err = r.ReplaceRoute("product", "/products/{slug}", http.MethodGet, "plain", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, ok := r.FindRouteByName("product.en"); ok {
+		t.Error(`expected locale routes to be replaced`)
+	}
+
+	route, ok := r.FindRouteByName("product")
+	if !ok || route.Template() != "/products/{slug}" || route.Action() != "plain" {
+		t.Errorf(`expected plain route "product" but got %v`, route)
+	}
+}
+
+func TestLocalizedRoute_ConcurrentReplaceRemove(t *testing.T) {
+	r := New()
+
+	err := r.AddLocalizedRoute("product", map[string]string{
+		"en": "/en/products/{slug}",
+		"de": "/de/produkte/{slug}",
+	}, []string{http.MethodGet}, nil, LocalizedOptions{DefaultLocale: "en"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByName("product")
+	if !ok {
+		t.Fatal(`expected route "product" to be found`)
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 100; i++ {
+			_ = r.ReplaceRoute("product.de", fmt.Sprintf("/de/artikel-%d/{slug}", i), http.MethodGet, nil, Options{})
+			_ = r.RemoveRoute("product.de")
+			_ = r.ReplaceRoute("product", "/products/{slug}", http.MethodGet, nil, Options{})
+			_ = r.RemoveRoute("product")
+			_ = r.AddLocalizedRoute("product", map[string]string{
+				"en": "/en/products/{slug}",
+				"de": "/de/produkte/{slug}",
+			}, []string{http.MethodGet}, nil, LocalizedOptions{DefaultLocale: "en"})
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, _ = route.URL(ParamsMap{"locale": "de", "slug": "schuh"})
+				_, _ = route.ExtractParams(httptest.NewRequest(http.MethodGet, "/de/produkte/schuh", nil))
+
+				if found, ok := r.FindRouteByName("product"); ok {
+					_, _ = found.URL(ParamsMap{"locale": "de", "slug": "schuh"})
+				}
+
+				if match, err := r.Match(httptest.NewRequest(http.MethodGet, "/en/products/shoe", nil)); err == nil {
+					_, _ = match.Route.URL(ParamsMap{"locale": "de", "slug": "schuh"})
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	result, err := route.URL(ParamsMap{"locale": "de", "slug": "schuh"})
+	if err != nil || result.String() != "/de/produkte/schuh" {
+		t.Errorf(`expected previously found route to keep url "/de/produkte/schuh" but got %v with %v`, result, err)
+	}
+}
@@ -0,0 +1,96 @@
+package router
+
+import (
+	"net/http"
+)
+
+type Match struct {
+	Route      Route
+	PathParams ParamsMap
+	HostParams ParamsMap
+	Defaults   ParamsMap
+}
+
+func (m Match) Params() ParamsMap {
+	return m.Defaults.Extend(m.HostParams).Extend(m.PathParams)
+}
+
+type Param struct {
+	Key     string
+	Value   string
+	Default bool
+}
+
+type Params []Param
+
+func (p Params) Get(key string) (string, bool) {
+	for _, param := range p {
+		if param.Key == key {
+			return param.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func (p Params) ToParamsMap() ParamsMap {
+	result := ParamsMap{}
+
+	for _, param := range p {
+		result[param.Key] = param.Value
+	}
+
+	return result
+}
+
+type routeMatch struct {
+	route  *route
+	result Route
+	host   string
+	path   string
+}
+
+func (m routeMatch) getRoute() Route {
+	if m.result != nil {
+		return m.result
+	}
+
+	return m.route
+}
+
+func (m routeMatch) toMatch() Match {
+	hostIndexes, pathIndexes := m.route.submatchIndexes(m.host, m.path)
+
+	return Match{
+		Route:      m.getRoute(),
+		PathParams: m.route.requiredParams.toParamsMap(m.path, pathIndexes),
+		HostParams: m.route.hostParams.toParamsMap(m.host, hostIndexes),
+		Defaults:   m.route.defaultParams.toParamsMap(),
+	}
+}
+
+func (m routeMatch) appendParams(params Params) Params {
+	hostIndexes, pathIndexes := m.route.submatchIndexes(m.host, m.path)
+
+	params = m.route.hostParams.appendParams(params, m.host, hostIndexes)
+	params = m.route.requiredParams.appendParams(params, m.path, pathIndexes)
+
+	captured := len(params)
+	for _, param := range m.route.defaultParamsList {
+		if _, ok := params[:captured].Get(param.Key); ok {
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	return params
+}
+
+func requestHost(request *http.Request) string {
+	if request.URL != nil && request.URL.Host != "" {
+		return request.URL.Host
+	}
+
+	return request.Host
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"time"
+)
+
+type Metadata map[string]interface{}
+
+func (m Metadata) Extend(other Metadata) Metadata {
+	result := Metadata{}
+
+	for k, v := range m {
+		result[k] = v
+	}
+
+	for k, v := range other {
+		result[k] = v
+	}
+
+	return result
+}
+
+func (m Metadata) Get(key string) (interface{}, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+func (m Metadata) String(key string) (string, bool) {
+	value, ok := m[key].(string)
+	return value, ok
+}
+
+func (m Metadata) Int(key string) (int, bool) {
+	value, ok := m[key].(int)
+	return value, ok
+}
+
+func (m Metadata) Bool(key string) (bool, bool) {
+	value, ok := m[key].(bool)
+	return value, ok
+}
+
+func (m Metadata) Strings(key string) ([]string, bool) {
+	value, ok := m[key].([]string)
+	return value, ok
+}
+
+func (m Metadata) Time(key string) (time.Time, bool) {
+	value, ok := m[key].(time.Time)
+	return value, ok
+}
+
+type tagsList []string
+
+func newTagsList(tags ...string) tagsList {
+	var result tagsList
+
+	for _, tag := range tags {
+		if tag == "" || result.has(tag) {
+			continue
+		}
+
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+func (t tagsList) has(tag string) bool {
+	for _, value := range t {
+		if value == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t tagsList) toSlice() []string {
+	if len(t) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(t))
+	copy(result, t)
+
+	return result
+}
@@ -0,0 +1,229 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type MountOptions struct {
+	Options
+	PreservePrefix bool
+}
+
+type mountHandler struct {
+	handler        http.Handler
+	prefix         *regexp.Regexp
+	basePath       basePath
+	preservePrefix bool
+}
+
+var _ http.Handler = &mountHandler{}
+
+func (g *routeGroup) Mount(name string, prefix string, handler http.Handler, options MountOptions) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.mount(name, prefix, handler, options)
+}
+
+func (g *routeGroup) mount(name string, prefix string, handler http.Handler, options MountOptions) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	route, err := g.createMountRoute(finalName, prefix, handler, options)
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(route)
+}
+
+func (g *routeGroup) createMountRoute(finalName string, prefix string, handler http.Handler, options MountOptions) (*route, error) {
+	if handler == nil {
+		handler = http.NotFoundHandler()
+	}
+
+	route, err := g.createRoute(finalName, prefix, nil, nil, options.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixRegexp, err := g.factory.createMountRegexp(route)
+	if err != nil {
+		return nil, err
+	}
+
+	route.action = &mountHandler{
+		handler:        handler,
+		prefix:         prefixRegexp,
+		basePath:       route.basePath,
+		preservePrefix: options.PreservePrefix,
+	}
+
+	return route, nil
+}
+
+// MountRouter copies the routes registered in the mounted router at the time
+// of the call, so later changes to the mounted router are not reflected.
+func (g *routeGroup) MountRouter(name string, prefix string, mounted Router, options Options) error {
+	source, ok := mounted.(*router)
+	if !ok {
+		return &RouteError{Route: name, Err: errors.New("mounted router is not supported")}
+	}
+
+	if source.group.lock == g.lock {
+		return &RouteError{Route: name, Err: errors.New("router can not be mounted into itself")}
+	}
+
+	// the source lock is released before the group lock is taken, so
+	// routers mounting each other at the same time can not deadlock
+	source.group.lock.RLock()
+	routes := source.group.snapshotRoutes(nil)
+	source.group.lock.RUnlock()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	group, err := g.addRouteGroup(name, prefix, options)
+	if err != nil {
+		return err
+	}
+
+	err = group.cloneRoutes(routes)
+	if err != nil {
+		g.removeRoute(group.name, true)
+		return err
+	}
+
+	return nil
+}
+
+// snapshotRoutes lists the routes of the group and its nested groups, which
+// stay unchanged after the lock is released, as changes replace them.
+func (g *routeGroup) snapshotRoutes(routes []routeFinder) []routeFinder {
+	for _, r := range g.routes {
+		if child, ok := r.(*routeGroup); ok {
+			routes = child.snapshotRoutes(routes)
+			continue
+		}
+
+		routes = append(routes, r)
+	}
+
+	return routes
+}
+
+// cloneRoutes registers the routes from the snapshot again, re-creating the
+// handlers which are bound to their routes, so they serve under the prefix
+// and resolve names of the group.
+func (g *routeGroup) cloneRoutes(routes []routeFinder) error {
+	for _, r := range routes {
+		var err error
+
+		switch child := r.(type) {
+		case *route:
+			err = g.cloneRoute(child)
+		case *localizedRoute:
+			paths := make(map[string]string, len(child.locales))
+			for locale, localeRoute := range child.locales {
+				paths[locale] = localeRoute.Template()
+			}
+
+			options := child.locales[child.defaultLocale].getOptions()
+			delete(options.DefaultParams, LocaleParam)
+
+			err = g.addLocalizedRoute(child.name, paths, child.Methods(), child.Action(), LocalizedOptions{
+				Options:       options,
+				DefaultLocale: child.defaultLocale,
+			})
+		case *routeAlias:
+			err = g.addRouteAlias(child.target, child.route.Template())
+		case *versionedRouteGroup:
+			err = &RouteError{Route: child.base.name, Err: errors.New("versioned route group can not be mounted")}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *routeGroup) cloneRoute(source *route) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), source.name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	route, err := g.createClone(finalName, source.Template(), source, func(target string) string {
+		return fmt.Sprintf("%s%s", g.getPrefix(), target)
+	})
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(route)
+}
+
+// createClone creates a copy of the source route under a new name and path,
+// re-creating the handlers which are bound to the source route.
+func (g *routeGroup) createClone(finalName string, path string, source *route, renameTarget func(target string) string) (*route, error) {
+	switch handler := source.action.(type) {
+	case *mountHandler:
+		return g.createMountRoute(finalName, path, handler.handler, MountOptions{
+			Options:        source.getOptions(),
+			PreservePrefix: handler.preservePrefix,
+		})
+	case *staticHandler:
+		options := handler.options
+		options.Options = source.getOptions()
+
+		return g.createStaticRoute(finalName, strings.TrimSuffix(path, fmt.Sprintf("{%s:.*}", StaticFileParam)), handler.fileSystem, options)
+	case *redirectHandler:
+		return g.createRedirectRoute(finalName, path, renameTarget(handler.target), handler.status, source.getOptions())
+	}
+
+	return g.createRoute(finalName, path, source.methods.toSlice(), source.action, source.getOptions())
+}
+
+func (h *mountHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if h.preservePrefix {
+		h.handler.ServeHTTP(writer, request)
+		return
+	}
+
+	path, ok := h.basePath.strip(request.URL.Path)
+	if !ok {
+		h.handler.ServeHTTP(writer, request)
+		return
+	}
+
+	indexes := h.prefix.FindStringIndex(path)
+	if indexes == nil {
+		h.handler.ServeHTTP(writer, request)
+		return
+	}
+
+	path = path[indexes[1]:]
+	if path == "" {
+		path = "/"
+	}
+
+	stripped := new(http.Request)
+	*stripped = *request
+	stripped.URL = new(url.URL)
+	*stripped.URL = *request.URL
+	stripped.URL.Path = path
+	stripped.URL.RawPath = ""
+
+	h.handler.ServeHTTP(writer, stripped)
+}
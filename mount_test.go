@@ -0,0 +1,239 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRouteGroup_Mount(t *testing.T) {
+	cases := []struct {
+		preservePrefix bool
+		url            string
+		found          bool
+		path           string
+	}{
+		{preservePrefix: false, url: "/tenants/acme/admin", found: true, path: "/"},
+		{preservePrefix: false, url: "/tenants/acme/admin/users/10", found: true, path: "/users/10"},
+		{preservePrefix: true, url: "/tenants/acme/admin/users/10", found: true, path: "/tenants/acme/admin/users/10"},
+		{preservePrefix: false, url: "/tenants/acme/administration", found: false},
+		{preservePrefix: false, url: "/tenants/acme", found: false},
+	}
+
+	for _, c := range cases {
+		r := New()
+
+		handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			_, _ = writer.Write([]byte(request.URL.Path))
+		})
+
+		err := r.Mount("admin", "/tenants/{tenant}/admin", handler, MountOptions{
+			PreservePrefix: c.preservePrefix,
+		})
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, c.url, nil)
+
+		match, err := r.Match(req)
+		if !c.found {
+			if err == nil {
+				t.Errorf(`expected "%s" not to be matched`, c.url)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+			continue
+		}
+
+		if match.PathParams["tenant"] != "acme" {
+			t.Errorf(`expected tenant "acme" but got "%s"`, match.PathParams["tenant"])
+		}
+
+		recorder := httptest.NewRecorder()
+		match.Route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+		if recorder.Body.String() != c.path {
+			t.Errorf(`expected handler path "%s" but got "%s"`, c.path, recorder.Body.String())
+		}
+	}
+}
+
+func TestRouteGroup_MountRouter(t *testing.T) {
+	admin := New()
+
+	users, err := admin.AddRouteGroup("users", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", "show")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	r := New()
+
+	err = r.MountRouter("admin", "/admin", admin, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByName("admin.users.show")
+	if !ok {
+		t.Fatal(`expected route "admin.users.show" to exist`)
+	}
+
+	result, err := route.URL(ParamsMap{"id": "10"})
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	} else if result.String() != "/admin/users/10" {
+		t.Errorf(`expected url "/admin/users/10" but got "%s"`, result.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/10", nil)
+	found, ok := r.FindRouteByRequest(req)
+	if !ok || found.Name() != "admin.users.show" || found.Action() != "show" {
+		t.Errorf(`expected route "admin.users.show" but got %v`, found)
+	}
+
+	err = r.MountRouter("self", "/self", r, Options{})
+	if err == nil {
+		t.Error(`expected error for mounting router into itself`)
+	}
+}
+
+func TestRouteGroup_MountRouterConcurrently(t *testing.T) {
+	first := New()
+
+	err := first.AddGetRoute("first", "/first", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	second := New()
+
+	err = second.AddGetRoute("second", "/second", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	var wg sync.WaitGroup
+	for _, pair := range [][2]Router{{first, second}, {second, first}} {
+		wg.Add(1)
+		go func(target Router, source Router) {
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+				name := fmt.Sprintf("mounted%d", i)
+
+				_ = target.MountRouter(name, "/"+name, source, Options{})
+				_ = target.RemoveRouteGroup(name)
+			}
+		}(pair[0], pair[1])
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal(`expected routers mounting each other to finish but they are blocked`)
+	}
+}
+
+func TestRouteGroup_MountRouterHandlers(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte(request.URL.Path))
+	})
+
+	admin := New()
+
+	err := admin.Mount("debug", "/debug/pprof", handler, MountOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = admin.AddStaticRoute("assets", "/assets", http.FS(fstest.MapFS{
+		"app.js": {Data: []byte("console.log(1)")},
+	}), StaticOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = admin.AddGetRoute("users", "/users", handler)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = admin.AddRedirectRoute("members", "/members", "users", http.StatusFound)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = admin.AddRouteAlias("users", "/people")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = admin.AddLocalizedRoute("product", map[string]string{"en": "/product", "de": "/produkt"}, nil, handler, LocalizedOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	r := New()
+
+	err = r.MountRouter("sub", "/sub", admin, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url      string
+		code     int
+		body     string
+		location string
+	}{
+		{url: "/sub/debug/pprof/heap", code: http.StatusOK, body: "/heap"},
+		{url: "/sub/assets/app.js", code: http.StatusOK, body: "console.log(1)"},
+		{url: "/sub/members", code: http.StatusFound, location: "/sub/users"},
+		{url: "/sub/people", code: http.StatusOK, body: "/sub/people"},
+		{url: "/sub/produkt", code: http.StatusOK, body: "/sub/produkt"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+
+		match, err := r.Match(req)
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		match.Route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+		if recorder.Code != c.code {
+			t.Errorf(`expected status %d for "%s" but got %d`, c.code, c.url, recorder.Code)
+		}
+
+		if c.body != "" && recorder.Body.String() != c.body {
+			t.Errorf(`expected body "%s" for "%s" but got "%s"`, c.body, c.url, recorder.Body.String())
+		}
+
+		if recorder.Header().Get("Location") != c.location {
+			t.Errorf(`expected location "%s" for "%s" but got "%s"`, c.location, c.url, recorder.Header().Get("Location"))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"strings"
+)
+
+// routeOrder decides which route wins when several of them match the same
+// request: higher priority first, then static path segments over params,
+// then the longer static prefix and finally the registration order.
+type routeOrder struct {
+	staticSegments []bool
+	staticPrefix   int
+	sequence       uint64
+}
+
+func newRouteOrder(segments pathTemplate, sequence uint64) routeOrder {
+	order := routeOrder{
+		sequence: sequence,
+	}
+
+	static := true
+	prefix := true
+
+	for _, segment := range segments {
+		if segment.param {
+			static = false
+			prefix = false
+			continue
+		}
+
+		if prefix {
+			order.staticPrefix += len(segment.value)
+		}
+
+		for index := 0; index < strings.Count(segment.value, "/"); index++ {
+			order.staticSegments = append(order.staticSegments, static)
+			static = true
+		}
+	}
+
+	order.staticSegments = append(order.staticSegments, static)
+
+	return order
+}
+
+func (r *route) precedes(other *route) bool {
+	if r.priority != other.priority {
+		return r.priority > other.priority
+	}
+
+	for index := 0; index < len(r.order.staticSegments) && index < len(other.order.staticSegments); index++ {
+		if r.order.staticSegments[index] != other.order.staticSegments[index] {
+			return r.order.staticSegments[index]
+		}
+	}
+
+	if r.order.staticPrefix != other.order.staticPrefix {
+		return r.order.staticPrefix > other.order.staticPrefix
+	}
+
+	return r.order.sequence < other.order.sequence
+}
+
+// orderRoutes lists the routes in the order matching resolves them: groups
+// pick the preceding route of their children, while localized routes and
+// versioned groups try their routes one after another.
+func orderRoutes(finder routeFinder) []*route {
+	switch child := finder.(type) {
+	case *route:
+		return []*route{child}
+	case *localizedRoute:
+		return child.collectRoutes(nil)
+	case *versionedRouteGroup:
+		var routes []*route
+		for _, version := range child.base.routes {
+			routes = append(routes, orderRoutes(version)...)
+		}
+
+		return routes
+	case *routeGroup:
+		lists := make([][]*route, 0, len(child.routes))
+		for _, r := range child.routes {
+			if routes := orderRoutes(r); len(routes) > 0 {
+				lists = append(lists, routes)
+			}
+		}
+
+		return mergeRoutes(lists)
+	}
+
+	return nil
+}
+
+// mergeRoutes merges the ordered lists by taking the preceding head, so the
+// order within each list is kept.
+func mergeRoutes(lists [][]*route) []*route {
+	var routes []*route
+
+	for len(lists) > 0 {
+		next := 0
+		for index := 1; index < len(lists); index++ {
+			if lists[index][0].precedes(lists[next][0]) {
+				next = index
+			}
+		}
+
+		routes = append(routes, lists[next][0])
+
+		lists[next] = lists[next][1:]
+		if len(lists[next]) == 0 {
+			lists = append(lists[:next], lists[next+1:]...)
+		}
+	}
+
+	return routes
+}
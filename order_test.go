@@ -0,0 +1,199 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRouter_FindRouteByRequestOrder(t *testing.T) {
+	r := New()
+
+	users, err := r.AddRouteGroup("users", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	admin, err := r.AddRouteGroup("admin", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v2, err := api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, route := range []struct {
+		group   RouteGroup
+		name    string
+		path    string
+		options Options
+	}{
+		{group: users, name: "show", path: "/{id}"},
+		{group: users, name: "file", path: "/{id}/{file}"},
+		{group: admin, name: "me", path: "/me"},
+		{group: admin, name: "settings", path: "/{id}/settings"},
+		{group: admin, name: "prefixed", path: "/id-{id}"},
+		{group: v1, name: "status", path: "/status"},
+		{group: v2, name: "status", path: "/status", options: Options{Priority: 5}},
+		{group: r, name: "catchAll", path: "/{path:.*}", options: Options{Priority: -1}},
+		{group: r, name: "important", path: "/users/{id}/avatar", options: Options{Priority: 10}},
+		{group: r, name: "other", path: "/{section}/{id}"},
+	} {
+		err = route.group.AddRoute(route.name, route.path, http.MethodGet, nil, route.options)
+		if err != nil {
+			t.Fatalf(`not expected error for "%s" but got %v`, route.name, err)
+		}
+	}
+
+	err = r.AddLocalizedRoute("product", map[string]string{
+		"de": "/{category}/{slug}/p",
+		"en": "/shop/{slug}/p",
+	}, []string{http.MethodGet}, nil, LocalizedOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url  string
+		name string
+	}{
+		{url: "/users/me", name: "admin.me"},
+		{url: "/users/id-10", name: "admin.prefixed"},
+		{url: "/users/10", name: "users.show"},
+		{url: "/users/10/settings", name: "admin.settings"},
+		{url: "/users/10/avatar", name: "important"},
+		{url: "/users/10/report", name: "users.file"},
+		{url: "/pages/10", name: "other"},
+		{url: "/pages", name: "catchAll"},
+		{url: "/api/v2/status", name: "api.v2.status"},
+		{url: "/shop/shoe/p", name: "product"},
+	}
+
+	for _, c := range cases {
+		route, ok := r.FindRouteByRequest(httptest.NewRequest(http.MethodGet, c.url, nil))
+		if !ok {
+			t.Errorf(`expected route "%s" for "%s" but got none`, c.name, c.url)
+			continue
+		}
+
+		if route.Name() != c.name {
+			t.Errorf(`expected route "%s" for "%s" but got "%s"`, c.name, c.url, route.Name())
+		}
+	}
+}
+
+func TestRouter_OrderedRoutes(t *testing.T) {
+	r := New()
+
+	users, err := r.AddRouteGroup("users", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	admin, err := r.AddRouteGroup("admin", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v2, err := api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, route := range []struct {
+		group   RouteGroup
+		name    string
+		path    string
+		options Options
+	}{
+		{group: users, name: "show", path: "/{id}"},
+		{group: users, name: "file", path: "/{id}/{file}"},
+		{group: admin, name: "me", path: "/me"},
+		{group: admin, name: "settings", path: "/{id}/settings"},
+		{group: admin, name: "prefixed", path: "/id-{id}"},
+		{group: v1, name: "status", path: "/status"},
+		{group: v2, name: "status", path: "/status", options: Options{Priority: 5}},
+		{group: r, name: "catchAll", path: "/{path:.*}", options: Options{Priority: -1}},
+		{group: r, name: "important", path: "/users/{id}/avatar", options: Options{Priority: 10}},
+		{group: r, name: "other", path: "/{section}/{id}"},
+	} {
+		err = route.group.AddRoute(route.name, route.path, http.MethodGet, nil, route.options)
+		if err != nil {
+			t.Fatalf(`not expected error for "%s" but got %v`, route.name, err)
+		}
+	}
+
+	err = r.AddLocalizedRoute("product", map[string]string{
+		"de": "/{category}/{slug}/p",
+		"en": "/shop/{slug}/p",
+	}, []string{http.MethodGet}, nil, LocalizedOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	var names []string
+	for _, route := range r.OrderedRoutes() {
+		names = append(names, route.Name())
+	}
+
+	expected := []string{
+		"important",
+		"api.v1.status",
+		"api.v2.status",
+		"admin.me",
+		"admin.prefixed",
+		"users.show",
+		"admin.settings",
+		"users.file",
+		"other",
+		"product.de",
+		"product.en",
+		"catchAll",
+	}
+
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf(`expected order %v but got %v`, expected, names)
+	}
+
+	match, err := r.Match(httptest.NewRequest(http.MethodGet, "/shop/shoe/p", nil))
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if match.Params()[LocaleParam] != "de" {
+		t.Errorf(`expected "product.de" to be matched before "product.en" but got %v`, match.Params())
+	}
+
+	err = r.ReplaceRoute("users.show", "/{id}", http.MethodGet, nil, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	routes := r.OrderedRoutes()
+	if routes[5].Name() != "users.show" {
+		t.Errorf(`expected replaced route to keep its position but got %v`, routes)
+	}
+}
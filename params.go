@@ -2,6 +2,8 @@ package router
 
 import (
 	"regexp"
+	"sort"
+	"strings"
 )
 
 type ParamsMap map[string]string
@@ -26,6 +28,102 @@ func (p ParamsMap) Extend(other ParamsMap) ParamsMap {
 
 type paramsList []string
 
+func (p paramsList) contains(key string) bool {
+	for _, value := range p {
+		if value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p paramsList) toParamsMap(value string, indexes []int) ParamsMap {
+	result := ParamsMap{}
+
+	for index, key := range p {
+		result[key] = p.valueAt(value, indexes, index)
+	}
+
+	return result
+}
+
+func (p paramsList) appendParams(params Params, value string, indexes []int) Params {
+	for index, key := range p {
+		params = append(params, Param{
+			Key:   key,
+			Value: p.valueAt(value, indexes, index),
+		})
+	}
+
+	return params
+}
+
+func (p paramsList) valueAt(value string, indexes []int, index int) string {
+	start, end := 2*(index+1), 2*(index+1)+1
+	if end >= len(indexes) || indexes[start] < 0 {
+		return ""
+	}
+
+	return value[indexes[start]:indexes[end]]
+}
+
+type methodsList []string
+
+func newMethodsList(methods ...string) methodsList {
+	var result methodsList
+
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" || result.has(method) {
+			continue
+		}
+
+		result = append(result, method)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func (m methodsList) contains(method string) bool {
+	return len(m) == 0 || m.has(method)
+}
+
+func (m methodsList) has(method string) bool {
+	for _, value := range m {
+		if value == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m methodsList) merge(other methodsList) methodsList {
+	for _, method := range other {
+		if !m.has(method) {
+			m = append(m, method)
+		}
+	}
+
+	sort.Strings(m)
+
+	return m
+}
+
+func (m methodsList) toSlice() []string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(m))
+	copy(result, m)
+
+	return result
+}
+
 type paramsValues map[string]string
 
 func (p paramsValues) toParamsMap() ParamsMap {
@@ -40,6 +138,24 @@ func (p paramsValues) toParamsMap() ParamsMap {
 	return result
 }
 
+func (p paramsValues) toParams() Params {
+	result := make(Params, 0, len(p))
+
+	for k, v := range p {
+		result = append(result, Param{
+			Key:     k,
+			Value:   v,
+			Default: true,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
 type paramsRequirements map[string]*regexp.Regexp
 
 func (p paramsRequirements) toParamsMap() ParamsMap {
@@ -53,3 +169,40 @@ func (p paramsRequirements) toParamsMap() ParamsMap {
 
 	return result
 }
+
+type basePath struct {
+	value       string
+	stripPrefix bool
+}
+
+func newBasePath(value string, strip bool) basePath {
+	value = strings.Trim(value, "/")
+	if value != "" {
+		value = "/" + value
+	}
+
+	return basePath{
+		value:       value,
+		stripPrefix: strip,
+	}
+}
+
+func (b basePath) apply(path string) string {
+	return b.value + path
+}
+
+func (b basePath) strip(path string) (string, bool) {
+	if !b.stripPrefix || b.value == "" {
+		return path, true
+	}
+
+	if path == b.value {
+		return "/", true
+	}
+
+	if !strings.HasPrefix(path, b.value+"/") {
+		return "", false
+	}
+
+	return path[len(b.value):], true
+}
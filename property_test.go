@@ -0,0 +1,42 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestRoute_URLExtractParamsProperty(t *testing.T) {
+	r := New()
+
+	err := r.AddGetRoute("page", "/pages/{slug}/{path:.*}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, _ := r.FindRouteByName("page")
+
+	property := func(slug string, path string) bool {
+		params := ParamsMap{"slug": slug, "path": path}
+
+		result, err := route.URL(params)
+		if err != nil {
+			return true
+		}
+
+		parsed, err := url.Parse(result.String())
+		if err != nil {
+			return false
+		}
+
+		extracted, err := route.ExtractParams(&http.Request{Method: http.MethodGet, URL: parsed})
+
+		return err == nil && reflect.DeepEqual(extracted, params)
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
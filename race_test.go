@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package router
+
+// raceEnabled skips allocation checks, as the race detector allocates on its own.
+const raceEnabled = true
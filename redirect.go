@@ -0,0 +1,250 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type redirectHandler struct {
+	route  *route
+	target string
+	status int
+	root   *routeGroup
+}
+
+var _ http.Handler = &redirectHandler{}
+
+type routeAlias struct {
+	route  *route
+	target string
+	root   *routeGroup
+}
+
+var _ routeFinder = &routeAlias{}
+
+type aliasedRoute struct {
+	Route
+	alias *route
+}
+
+var _ Route = &aliasedRoute{}
+
+// AddRedirectRoute resolves the target name relative to the group, the same
+// way as AddRouteAlias does.
+func (g *routeGroup) AddRedirectRoute(name string, fromPath string, toRouteName string, status int) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.addRedirectRoute(name, fromPath, toRouteName, status)
+}
+
+func (g *routeGroup) addRedirectRoute(name string, fromPath string, toRouteName string, status int) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	target := fmt.Sprintf("%s%s", g.getPrefix(), toRouteName)
+
+	if _, ok := g.factory.findRouteByName(target); !ok {
+		return &RouteError{Route: target, Err: ErrRouteNotFound}
+	}
+
+	route, err := g.createRedirectRoute(finalName, fromPath, target, status, Options{})
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(route)
+}
+
+func (g *routeGroup) createRedirectRoute(finalName string, fromPath string, toRouteName string, status int, options Options) (*route, error) {
+	if status == 0 {
+		status = http.StatusMovedPermanently
+	}
+
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, &RouteError{Route: finalName, Err: fmt.Errorf(`invalid redirect status %d`, status)}
+	}
+
+	route, err := g.createRoute(finalName, fromPath, nil, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	route.action = &redirectHandler{
+		route:  route,
+		target: toRouteName,
+		status: status,
+		root:   g.factory.root,
+	}
+
+	return route, nil
+}
+
+func (g *routeGroup) AddRouteAlias(routeName string, path string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.addRouteAlias(routeName, path)
+}
+
+func (g *routeGroup) addRouteAlias(routeName string, path string) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), routeName)
+
+	found, ok := g.factory.findRouteByName(finalName)
+	if !ok {
+		return &RouteError{Route: finalName, Err: ErrRouteNotFound}
+	}
+
+	target, ok := found.(*route)
+	if !ok {
+		return &RouteError{Route: finalName, Err: fmt.Errorf(`route can not be aliased`)}
+	}
+
+	alias, err := g.createRoute(finalName, path, target.methods, target.action, target.getOptions())
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(&routeAlias{
+		route:  alias,
+		target: finalName,
+		root:   g.factory.root,
+	})
+}
+
+func (h *redirectHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	params, err := h.route.ExtractParams(request)
+	if err != nil {
+		http.NotFound(writer, request)
+		return
+	}
+
+	h.root.lock.RLock()
+	target, ok := h.root.factory.findRouteByName(h.target)
+	h.root.lock.RUnlock()
+
+	if !ok {
+		http.NotFound(writer, request)
+		return
+	}
+
+	location, err := h.generateURL(target, params, request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if location.RawQuery == "" {
+		location.RawQuery = request.URL.RawQuery
+	}
+
+	http.Redirect(writer, request, location.String(), h.status)
+}
+
+func (h *redirectHandler) generateURL(target Route, params ParamsMap, request *http.Request) (*url.URL, error) {
+	generator, ok := target.(contextURLGenerator)
+	if !ok {
+		return target.URL(params)
+	}
+
+	return generator.generateURL(params, AbsolutePath, newURLContext(request))
+}
+
+func (a *routeAlias) findRouteByRequest(request *http.Request) (Route, bool) {
+	match, ok := a.matchRequest(request)
+	if !ok {
+		return nil, false
+	}
+
+	return match.getRoute(), true
+}
+
+func (a *routeAlias) findRouteByName(name string) (Route, bool) {
+	return nil, false
+}
+
+func (a *routeAlias) matchRequest(request *http.Request) (routeMatch, bool) {
+	match, ok := a.route.matchRequest(request)
+	if !ok {
+		return routeMatch{}, false
+	}
+
+	target, ok := a.root.factory.findRouteByName(a.target)
+	if !ok {
+		return routeMatch{}, false
+	}
+
+	match.result = &aliasedRoute{
+		Route: target,
+		alias: a.route,
+	}
+
+	return match, true
+}
+
+func (a *routeAlias) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	return a.route.allowedMethods(request, methods)
+}
+
+func (r *aliasedRoute) ExtractParams(request *http.Request) (ParamsMap, error) {
+	params, err := r.alias.ExtractPathParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Route.DefaultParams().Extend(params), nil
+}
+
+func (r *aliasedRoute) ExtractPathParams(request *http.Request) (ParamsMap, error) {
+	return r.alias.ExtractPathParams(request)
+}
+
+func (r *aliasedRoute) generateURL(params ParamsMap, referenceType ReferenceType, context urlContext) (*url.URL, error) {
+	generator, ok := r.Route.(contextURLGenerator)
+	if !ok {
+		return r.Route.GenerateURL(params, referenceType)
+	}
+
+	return generator.generateURL(params, referenceType, context)
+}
+
+func (g *routeGroup) removeDanglingAliases() error {
+	var routes []routeFinder
+	removed := false
+
+	for _, r := range g.routes {
+		var err error
+
+		switch child := r.(type) {
+		case *routeAlias:
+			if _, ok := g.factory.findRouteByName(child.target); !ok {
+				removed = true
+				continue
+			}
+		case *routeGroup:
+			err = child.removeDanglingAliases()
+		case *versionedRouteGroup:
+			err = child.base.removeDanglingAliases()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		routes = append(routes, r)
+	}
+
+	if !removed {
+		return nil
+	}
+
+	g.routes = routes
+
+	return g.factory.update(g)
+}
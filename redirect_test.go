@@ -0,0 +1,173 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRouteGroup_AddRedirectRoute(t *testing.T) {
+	r := New()
+
+	err := r.AddGetRoute("article", "/articles/{slug}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRedirectRoute("article.old", "/blog/{slug}.html", "article", 0)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, status := range []int{http.StatusOK, http.StatusMultipleChoices, http.StatusNotModified, http.StatusUseProxy} {
+		err = r.AddRedirectRoute("article.invalid", "/news/{slug}", "article", status)
+		if err == nil {
+			t.Errorf(`expected error for invalid redirect status %d`, status)
+		}
+	}
+
+	err = r.AddRedirectRoute("article.missing", "/news/{slug}", "missing", 0)
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf(`expected error %v but got %v`, ErrRouteNotFound, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/blog/hello.html?ref=feed", nil)
+
+	route, ok := r.FindRouteByRequest(req)
+	if !ok || route.Name() != "article.old" {
+		t.Fatalf(`expected route "article.old" but got %v`, route)
+	}
+
+	recorder := httptest.NewRecorder()
+	route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusMovedPermanently {
+		t.Errorf(`expected status %d but got %d`, http.StatusMovedPermanently, recorder.Code)
+	}
+	if recorder.Header().Get("Location") != "/articles/hello?ref=feed" {
+		t.Errorf(`expected location "/articles/hello?ref=feed" but got "%s"`, recorder.Header().Get("Location"))
+	}
+}
+
+func TestRouteGroup_AddRedirectRouteInGroup(t *testing.T) {
+	r := New()
+
+	api, err := r.AddRouteGroup("api", "/api", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddGetRoute("user", "/users/{id}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddRouteAlias("user", "/people/{id}")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddRedirectRoute("old", "/members/{id}", "user", http.StatusMovedPermanently)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = api.AddRedirectRoute("absolute", "/accounts/{id}", "api.user", http.StatusMovedPermanently)
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf(`expected error %v but got %v`, ErrRouteNotFound, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/10", nil)
+
+	route, ok := r.FindRouteByRequest(req)
+	if !ok || route.Name() != "api.old" {
+		t.Fatalf(`expected route "api.old" but got %v`, route)
+	}
+
+	recorder := httptest.NewRecorder()
+	route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+	if recorder.Header().Get("Location") != "/api/users/10" {
+		t.Errorf(`expected location "/api/users/10" but got "%s"`, recorder.Header().Get("Location"))
+	}
+}
+
+func TestRouteGroup_AddRouteAlias(t *testing.T) {
+	r := New()
+
+	group, err := r.AddRouteGroup("shop", "/shop", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddGetRoute("product", "/products/{id:[0-9]+}", "product")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddRouteAlias("product", "/items/{id:[0-9]+}")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddRouteAlias("missing", "/missing")
+	if err == nil {
+		t.Error(`expected error for alias of missing route`)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/shop/items/10", nil)
+
+	match, err := r.Match(req)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if match.Route.Name() != "shop.product" || match.Route.Action() != "product" {
+		t.Errorf(`expected route "shop.product" but got "%s"`, match.Route.Name())
+	}
+
+	params, err := match.Route.ExtractParams(req)
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	} else if !reflect.DeepEqual(params, ParamsMap{"id": "10"}) {
+		t.Errorf(`expected params %v but got %v`, ParamsMap{"id": "10"}, params)
+	}
+
+	result, err := match.Route.URL(params)
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	} else if result.String() != "/shop/products/10" {
+		t.Errorf(`expected canonical url "/shop/products/10" but got "%s"`, result.String())
+	}
+}
+
+func TestRouteGroup_RemoveAliasedRoute(t *testing.T) {
+	r := New()
+
+	err := r.AddGetRoute("product", "/products/{id}", "product")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRouteAlias("product", "/items/{id}")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.RemoveRoute("product")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("product", "/catalog/{id}", "catalog")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	_, err = r.Match(httptest.NewRequest(http.MethodGet, "/items/10", nil))
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf(`expected alias to be removed with its route but got %v`, err)
+	}
+}
@@ -1,27 +1,40 @@
 package router
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
-	"strings"
 )
 
 type route struct {
 	name               string
 	action             Action
 	priority           int
-	method             string
+	methods            methodsList
 	secure             bool
 	host               string
+	hostRegexp         *regexp.Regexp
+	hostParams         paramsList
+	hostSegments       pathTemplate
 	forwardRegexp      *regexp.Regexp
 	reversePath        string
+	template           string
+	segments           pathTemplate
+	urlSegments        pathTemplate
 	requiredParams     paramsList
 	paramsRequirements paramsRequirements
+	anchored           paramsRequirements
 	defaultParams      paramsValues
+	defaultParamsList  Params
 	requirement        *regexp.Regexp
+	anchoredDefault    *regexp.Regexp
+	basePath           basePath
+	collectErrors      bool
+	urlModifier        func(result *url.URL, params ParamsMap) error
+	metadata           Metadata
+	tags               tagsList
+	deprecation        *Deprecation
+	order              routeOrder
 }
 
 var _ Route = &route{}
@@ -39,131 +52,333 @@ func (r *route) Path() string {
 	return r.reversePath
 }
 
+func (r *route) Template() string {
+	if r.template == "" {
+		return r.reversePath
+	}
+
+	return r.template
+}
+
+func (r *route) Methods() []string {
+	return r.methods.toSlice()
+}
+
 func (r *route) Action() Action {
 	return r.action
 }
 
+func (r *route) DefaultParams() ParamsMap {
+	return r.defaultParams.toParamsMap()
+}
+
+func (r *route) Metadata() Metadata {
+	return r.metadata.Extend(nil)
+}
+
+func (r *route) Tags() []string {
+	return r.tags.toSlice()
+}
+
+func (r *route) HasTag(tag string) bool {
+	return r.tags.has(tag)
+}
+
+func (r *route) Deprecation() (Deprecation, bool) {
+	if r.deprecation == nil {
+		return Deprecation{}, false
+	}
+
+	return *r.deprecation, true
+}
+
 func (r *route) URL(params ParamsMap) (*url.URL, error) {
-	finalParams := r.defaultParams.toParamsMap().Extend(params)
+	return r.GenerateURL(params, AbsolutePath)
+}
+
+func (r *route) UncheckedURL(params ParamsMap) (*url.URL, error) {
+	return r.buildURL(params, AbsolutePath, urlContext{}, false)
+}
+
+func (r *route) GenerateURL(params ParamsMap, referenceType ReferenceType) (*url.URL, error) {
+	return r.generateURL(params, referenceType, urlContext{})
+}
+
+func (r *route) generateURL(params ParamsMap, referenceType ReferenceType, context urlContext) (*url.URL, error) {
+	return r.buildURL(params, referenceType, context, true)
+}
 
-	err := r.checkParams(finalParams)
+func (r *route) buildURL(params ParamsMap, referenceType ReferenceType, context urlContext, validate bool) (*url.URL, error) {
+	var err error
+	if validate {
+		err = r.checkParams(params)
+	} else {
+		err = r.checkRequiredParams(params)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	path, err := r.buildPath(finalParams)
+	path, rawPath, err := r.buildURLPaths(params)
 	if err != nil {
 		return nil, err
 	}
 
-	scheme := "http"
+	host := r.host
+	if r.hostRegexp != nil {
+		host, _ = r.hostSegments.build(params, r.defaultParams)
+	}
+
+	scheme := context.scheme
 	if r.secure {
 		scheme = "https"
+	} else if scheme == "" {
+		scheme = "http"
+	}
+
+	result, err := context.generate(referenceType, scheme, host, path, rawPath)
+	if err != nil {
+		return nil, err
 	}
 
-	return &url.URL{
-		Scheme: scheme,
-		Host:   r.host,
-		Path:   path,
-	}, nil
+	result.Fragment = lookupParam(params, r.defaultParams, FragmentParam)
+
+	if r.urlModifier != nil {
+		err = r.urlModifier(result, r.defaultParams.toParamsMap().Extend(params))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 func (r *route) ExtractParams(request *http.Request) (ParamsMap, error) {
+	result, err := r.ExtractPathParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.defaultParams.toParamsMap().Extend(result), nil
+}
+
+func (r *route) ExtractPathParams(request *http.Request) (ParamsMap, error) {
 	if request == nil || request.URL == nil {
-		return nil, errors.New("url is not provided")
+		return nil, ErrURLNotProvided
 	}
 
-	matches, err := r.getMatchesPath(request.URL)
-	if err != nil {
-		return nil, err
+	path, _ := r.basePath.strip(request.URL.Path)
+
+	pathIndexes := r.matchPath(path)
+	if pathIndexes == nil {
+		return nil, ErrURLMismatch
 	}
 
-	result := ParamsMap{}
-	for index, key := range r.requiredParams {
-		result[key] = matches[0][index+1]
+	result := r.requiredParams.toParamsMap(path, pathIndexes)
+
+	host := requestHost(request)
+	if hostIndexes, ok := r.matchHost(host); ok {
+		for key, value := range r.hostParams.toParamsMap(host, hostIndexes) {
+			result[key] = value
+		}
 	}
 
 	return result, nil
 }
 
 func (r *route) buildPath(params ParamsMap) (string, error) {
-	path := r.reversePath
+	path, _, err := r.buildPaths(params)
+	return path, err
+}
 
-	for key, value := range params {
-		wrapped := fmt.Sprintf("{%s}", key)
-		if strings.Index(path, wrapped) == -1 {
-			continue
+func (r *route) buildPaths(params ParamsMap) (string, string, error) {
+	segments := r.segments
+	if segments == nil {
+		parsed, err := parseTemplate(r.reversePath)
+		if err != nil {
+			return "", "", err
 		}
 
-		path = strings.Replace(path, wrapped, value, 1)
+		segments = parsed
 	}
 
-	return path, nil
+	path, rawPath := segments.build(params, r.defaultParams)
+
+	return path, rawPath, nil
 }
 
-func (r *route) checkParams(params ParamsMap) error {
-	checked := map[string]bool{}
+func (r *route) buildURLPaths(params ParamsMap) (string, string, error) {
+	if r.urlSegments != nil {
+		path, rawPath := r.urlSegments.build(params, r.defaultParams)
+		return path, rawPath, nil
+	}
+
+	path, rawPath, err := r.buildPaths(params)
+	if err != nil {
+		return "", "", err
+	}
 
-	for _, key := range r.requiredParams {
-		checked[key] = true
+	return r.basePath.apply(path), r.basePath.apply(rawPath), nil
+}
+
+func (r *route) checkParams(params ParamsMap) error {
+	collector := &errorCollector{
+		collect: r.collectErrors,
+	}
 
-		if params == nil {
-			return fmt.Errorf(`param "%s" is not provided`, key)
+	for _, required := range []paramsList{r.requiredParams, r.hostParams} {
+		for _, key := range required {
+			err := r.checkParam(key, params, true)
+			if err != nil && !collector.add(err) {
+				return collector.err()
+			}
 		}
+	}
 
-		value, ok := params[key]
-		if !ok {
-			return fmt.Errorf(`param "%s" is not provided`, key)
+	for key := range r.paramsRequirements {
+		if r.requiredParams.contains(key) || r.hostParams.contains(key) {
+			continue
 		}
 
-		requirement, ok := r.paramsRequirements[key]
-		if !ok {
-			requirement = r.requirement
+		err := r.checkParam(key, params, false)
+		if err != nil && !collector.add(err) {
+			return collector.err()
 		}
+	}
 
-		matches := requirement.FindAllString(value, 1)
-		if len(matches) == 0 || matches[0] != value {
-			return fmt.Errorf(`invalid format provided for param "%s"`, key)
+	return collector.err()
+}
+
+func (r *route) checkRequiredParams(params ParamsMap) error {
+	collector := &errorCollector{
+		collect: r.collectErrors,
+	}
+
+	for _, required := range []paramsList{r.requiredParams, r.hostParams} {
+		for _, key := range required {
+			if _, ok := r.paramValue(key, params); ok {
+				continue
+			}
+
+			err := &ParamError{
+				Route: r.name,
+				Param: key,
+				Err:   ErrMissingParam,
+			}
+			if !collector.add(err) {
+				return collector.err()
+			}
 		}
 	}
 
-	for key, compiled := range r.paramsRequirements {
-		if checked[key] {
-			continue
+	return collector.err()
+}
+
+func (r *route) checkParam(key string, params ParamsMap, required bool) error {
+	value, ok := r.paramValue(key, params)
+	if !ok {
+		if !required {
+			return nil
 		}
 
-		value, ok := params[key]
-		if !ok {
-			continue
+		return &ParamError{
+			Route: r.name,
+			Param: key,
+			Err:   ErrMissingParam,
 		}
+	}
 
-		matches := compiled.FindAllString(value, 1)
-		if len(matches) == 0 || matches[0] != value {
-			return fmt.Errorf(`invalid format provided for param "%s"`, key)
+	requirement, anchored := r.requirement, r.anchoredDefault
+	if custom, ok := r.paramsRequirements[key]; ok {
+		requirement, anchored = custom, r.anchored[key]
+	}
+
+	if !matchesValue(requirement, anchored, value) {
+		return &ParamError{
+			Route:       r.name,
+			Param:       key,
+			Value:       value,
+			Requirement: requirement.String(),
+			Err:         ErrInvalidParam,
 		}
 	}
 
 	return nil
 }
 
+// matchesValue checks the whole value with the anchored requirement, which
+// does not allocate, and falls back to the requirement for routes created
+// without one.
+func matchesValue(requirement *regexp.Regexp, anchored *regexp.Regexp, value string) bool {
+	if anchored != nil {
+		return anchored.MatchString(value)
+	}
+
+	indexes := requirement.FindStringIndex(value)
+
+	return indexes != nil && indexes[0] == 0 && indexes[1] == len(value)
+}
+
+func (r *route) paramValue(key string, params ParamsMap) (string, bool) {
+	if value, ok := params[key]; ok {
+		return value, true
+	}
+
+	value, ok := r.defaultParams[key]
+	return value, ok
+}
+
+func (r *route) getOptions() Options {
+	return Options{
+		Priority:      r.priority,
+		Secure:        r.secure,
+		Host:          r.host,
+		DefaultParams: r.defaultParams.toParamsMap(),
+		Metadata:      r.metadata.Extend(nil),
+		Tags:          r.tags.toSlice(),
+		Deprecation:   r.deprecation,
+	}
+}
+
 func (r *route) findRouteByRequest(request *http.Request) (Route, bool) {
-	if request == nil || request.URL == nil {
+	match, ok := r.matchRequest(request)
+	if !ok {
 		return nil, false
 	}
 
-	if !r.matchesHost(request.URL) {
-		return nil, false
+	return match.getRoute(), true
+}
+
+func (r *route) matchRequest(request *http.Request) (routeMatch, bool) {
+	if request == nil || request.URL == nil {
+		return routeMatch{}, false
+	}
+
+	host := requestHost(request)
+	if !r.hostMatches(host) {
+		return routeMatch{}, false
 	}
 
+	return r.matchRequestPath(request, host)
+}
+
+// matchRequestPath skips the host check, for routes of a host partition
+// which was already matched.
+func (r *route) matchRequestPath(request *http.Request, host string) (routeMatch, bool) {
 	if !r.matchesMethod(request) {
-		return nil, false
+		return routeMatch{}, false
 	}
 
-	if !r.matchesPath(request.URL) {
-		return nil, false
+	path, ok := r.basePath.strip(request.URL.Path)
+	if !ok || !r.pathMatches(path) {
+		return routeMatch{}, false
 	}
 
-	return r, true
+	return routeMatch{
+		route: r,
+		host:  host,
+		path:  path,
+	}, true
 }
 
 func (r *route) findRouteByName(name string) (Route, bool) {
@@ -178,24 +393,84 @@ func (r *route) findRouteByName(name string) (Route, bool) {
 	return r, true
 }
 
+func (r *route) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	if request == nil || request.URL == nil {
+		return methods
+	}
+
+	if !r.hostMatches(requestHost(request)) {
+		return methods
+	}
+
+	path, ok := r.basePath.strip(request.URL.Path)
+	if !ok || !r.pathMatches(path) {
+		return methods
+	}
+
+	return methods.merge(r.methods)
+}
+
 func (r *route) matchesHost(requestURL *url.URL) bool {
-	return r.host == "" || r.host == requestURL.Host
+	return r.hostMatches(requestURL.Host)
+}
+
+func (r *route) matchHost(host string) ([]int, bool) {
+	if r.hostRegexp == nil {
+		return nil, r.host == "" || r.host == host
+	}
+
+	indexes := r.hostRegexp.FindStringSubmatchIndex(host)
+	if len(indexes) != 2*(len(r.hostParams)+1) {
+		return nil, false
+	}
+
+	return indexes, true
 }
 
 func (r *route) matchesMethod(request *http.Request) bool {
-	return r.method == "" || r.method == request.Method
+	return r.methods.contains(request.Method)
 }
 
 func (r *route) matchesPath(requestURL *url.URL) bool {
-	_, err := r.getMatchesPath(requestURL)
-	return err == nil
+	path, ok := r.basePath.strip(requestURL.Path)
+	return ok && r.pathMatches(path)
+}
+
+func (r *route) hostMatches(host string) bool {
+	if r.hostRegexp == nil {
+		return r.host == "" || r.host == host
+	}
+
+	return r.hostRegexp.NumSubexp() == len(r.hostParams) && r.hostRegexp.MatchString(host)
+}
+
+func (r *route) pathMatches(path string) bool {
+	return r.forwardRegexp.NumSubexp() == len(r.requiredParams) && r.forwardRegexp.MatchString(path)
+}
+
+// submatchIndexes runs the capturing regexps only for patterns with params,
+// so a matched route without params is resolved without allocating. Only the
+// winning route runs them, after MatchString already matched it, as capturing
+// while scanning makes every candidate slower and lookups by request allocate.
+func (r *route) submatchIndexes(host string, path string) ([]int, []int) {
+	var hostIndexes, pathIndexes []int
+
+	if len(r.hostParams) > 0 {
+		hostIndexes, _ = r.matchHost(host)
+	}
+
+	if len(r.requiredParams) > 0 {
+		pathIndexes = r.matchPath(path)
+	}
+
+	return hostIndexes, pathIndexes
 }
 
-func (r *route) getMatchesPath(requestURL *url.URL) ([][]string, error) {
-	matches := r.forwardRegexp.FindAllStringSubmatch(requestURL.Path, 1)
-	if len(matches) != 1 || len(matches[0]) != len(r.requiredParams)+1 {
-		return nil, errors.New("url does not belong to route")
+func (r *route) matchPath(path string) []int {
+	indexes := r.forwardRegexp.FindStringSubmatchIndex(path)
+	if len(indexes) != 2*(len(r.requiredParams)+1) {
+		return nil
 	}
 
-	return matches, nil
+	return indexes
 }
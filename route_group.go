@@ -7,11 +7,14 @@ import (
 	pathLib "path"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 type routeFinder interface {
 	findRouteByRequest(request *http.Request) (Route, bool)
 	findRouteByName(name string) (Route, bool)
+	matchRequest(request *http.Request) (routeMatch, bool)
+	allowedMethods(request *http.Request, methods methodsList) methodsList
 }
 
 type routeGroup struct {
@@ -25,6 +28,12 @@ type routeGroup struct {
 	defaultParams      paramsValues
 	routes             []routeFinder
 	factory            *factory
+	basePath           basePath
+	lock               *sync.RWMutex
+	metadata           Metadata
+	tags               tagsList
+	deprecation        *Deprecation
+	hostMatched        bool
 }
 
 var _ RouteGroup = &routeGroup{}
@@ -35,24 +44,14 @@ func (g *routeGroup) Name() string {
 }
 
 func (g *routeGroup) AddRoute(name string, path string, method string, action Action, options Options) error {
-	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
-
-	if _, ok := g.findRouteByName(finalName); ok {
-		return fmt.Errorf(`route with name "%s" already exists`, finalName)
-	}
-
-	finalPath := pathLib.Join(g.originalPath, path)
-
-	options = g.getOptions(options)
-
-	route, err := g.factory.createRoute(finalName, finalPath, method, action, options)
-	if err != nil {
-		return err
-	}
+	return g.AddMethodsRoute(name, path, []string{method}, action, options)
+}
 
-	g.routes = append(g.routes, route)
+func (g *routeGroup) AddMethodsRoute(name string, path string, methods []string, action Action, options Options) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
 
-	return nil
+	return g.addRoute(name, path, methods, action, options)
 }
 
 func (g *routeGroup) AddDeleteRoute(name string, path string, action Action) error {
@@ -84,10 +83,95 @@ func (g *routeGroup) AddPutRoute(name string, path string, action Action) error
 }
 
 func (g *routeGroup) AddRouteGroup(name string, path string, options Options) (RouteGroup, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	group, err := g.addRouteGroup(name, path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (g *routeGroup) ReplaceRoute(name string, path string, method string, action Action, options Options) error {
+	return g.ReplaceMethodsRoute(name, path, []string{method}, action, options)
+}
+
+func (g *routeGroup) ReplaceMethodsRoute(name string, path string, methods []string, action Action, options Options) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
 	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
 
-	if _, ok := g.findRouteByName(finalName); ok {
-		return nil, fmt.Errorf(`route with name "%s" already exists`, finalName)
+	ok, err := g.replaceRoute(finalName, path, methods, action, options)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &RouteError{Route: finalName, Err: ErrRouteNotFound}
+	}
+
+	return nil
+}
+
+func (g *routeGroup) RemoveRoute(name string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	ok, err := g.removeRoute(finalName, false)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &RouteError{Route: finalName, Err: ErrRouteNotFound}
+	}
+
+	return g.factory.root.removeDanglingAliases()
+}
+
+func (g *routeGroup) RemoveRouteGroup(name string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	ok, err := g.removeRoute(finalName, true)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &RouteError{Route: finalName, Err: ErrRouteNotFound}
+	}
+
+	return g.factory.root.removeDanglingAliases()
+}
+
+func (g *routeGroup) addRoute(name string, path string, methods []string, action Action, options Options) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	route, err := g.createRoute(finalName, path, methods, action, options)
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(route)
+}
+
+func (g *routeGroup) addRouteGroup(name string, path string, options Options) (*routeGroup, error) {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return nil, &RouteError{Route: finalName, Err: ErrDuplicateRoute}
 	}
 
 	finalPath := pathLib.Join(g.originalPath, path)
@@ -99,34 +183,224 @@ func (g *routeGroup) AddRouteGroup(name string, path string, options Options) (R
 		return nil, err
 	}
 
-	g.routes = append(g.routes, group)
+	err = g.appendRoute(group)
+	if err != nil {
+		return nil, err
+	}
 
 	return group, nil
 }
 
+func (g *routeGroup) appendRoute(finder routeFinder) error {
+	g.routes = append(g.routes, finder)
+	g.factory.indexRoute(finder)
+
+	err := g.factory.update(g)
+	if err != nil {
+		g.routes = g.routes[:len(g.routes)-1]
+		g.factory.unindexRoute(finder)
+		_ = g.factory.update(g)
+		return err
+	}
+
+	return nil
+}
+
+func (g *routeGroup) createRoute(finalName string, path string, methods []string, action Action, options Options) (*route, error) {
+	finalPath := pathLib.Join(g.originalPath, path)
+
+	options = g.getOptions(options)
+
+	return g.factory.createRoute(finalName, finalPath, newMethodsList(methods...), action, options)
+}
+
+func (g *routeGroup) replaceRoute(finalName string, path string, methods []string, action Action, options Options) (bool, error) {
+	for index, r := range g.routes {
+		switch child := r.(type) {
+		case *route:
+			if child.name != finalName {
+				continue
+			}
+
+			replacement, err := g.createRoute(finalName, path, methods, action, options)
+			if err != nil {
+				return false, err
+			}
+
+			replacement.order.sequence = child.order.sequence
+
+			g.factory.unindexRoute(child)
+			g.routes[index] = replacement
+			g.factory.indexRoute(replacement)
+			return true, g.factory.update(g)
+		case *localizedRoute:
+			if child.name == finalName {
+				replacement, err := g.createRoute(finalName, path, methods, action, options)
+				if err != nil {
+					return false, err
+				}
+
+				replacement.order.sequence = child.locales[child.defaultLocale].order.sequence
+
+				g.factory.unindexRoute(child)
+				g.routes[index] = replacement
+				g.factory.indexRoute(replacement)
+				return true, g.factory.update(g)
+			}
+
+			locale, ok := child.findLocale(finalName)
+			if !ok {
+				continue
+			}
+
+			replacement, err := g.createRoute(finalName, path, methods, action, child.localeOptions(options, locale))
+			if err != nil {
+				return false, err
+			}
+
+			replacement.order.sequence = child.locales[locale].order.sequence
+
+			localized := child.withLocale(locale, replacement)
+
+			g.factory.unindexRoute(child)
+			g.routes[index] = localized
+			g.factory.indexRoute(localized)
+			return true, g.factory.update(g)
+		case *routeGroup:
+			if !strings.HasPrefix(finalName, child.getPrefix()) {
+				continue
+			}
+
+			ok, err := child.replaceRoute(finalName, path, methods, action, options)
+			if ok || err != nil {
+				return ok, err
+			}
+		case *versionedRouteGroup:
+			if !strings.HasPrefix(finalName, child.base.getPrefix()) {
+				continue
+			}
+
+			ok, err := child.base.replaceRoute(finalName, path, methods, action, options)
+			if ok || err != nil {
+				return ok, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (g *routeGroup) removeRoute(finalName string, group bool) (bool, error) {
+	for index, r := range g.routes {
+		removable := false
+
+		switch child := r.(type) {
+		case *route:
+			removable = !group && child.name == finalName
+		case *localizedRoute:
+			removable = !group && child.name == finalName
+
+			if locale, ok := child.findLocale(finalName); !group && ok {
+				if len(child.localesList) == 1 {
+					removable = true
+					break
+				}
+
+				localized := child.withoutLocale(locale)
+
+				g.factory.unindexRoute(child)
+				g.routes[index] = localized
+				g.factory.indexRoute(localized)
+				return true, g.factory.update(g)
+			}
+		case *routeGroup:
+			removable = group && child.name == finalName
+
+			if !removable && strings.HasPrefix(finalName, child.getPrefix()) {
+				if ok, err := child.removeRoute(finalName, group); ok || err != nil {
+					return ok, err
+				}
+			}
+		case *versionedRouteGroup:
+			removable = group && child.base.name == finalName
+
+			if !removable && strings.HasPrefix(finalName, child.base.getPrefix()) {
+				if ok, err := child.base.removeRoute(finalName, group); ok || err != nil {
+					return ok, err
+				}
+			}
+		}
+
+		if removable {
+			g.factory.unindexRoute(r)
+			g.routes = append(g.routes[:index:index], g.routes[index+1:]...)
+			return true, g.factory.update(g)
+		}
+	}
+
+	return false, nil
+}
+
 func (g *routeGroup) findRouteByRequest(request *http.Request) (Route, bool) {
-	if request == nil || request.URL == nil {
+	match, ok := g.matchRequest(request)
+	if !ok {
 		return nil, false
 	}
 
+	return match.getRoute(), true
+}
+
+func (g *routeGroup) matchRequest(request *http.Request) (routeMatch, bool) {
+	if request == nil || request.URL == nil {
+		return routeMatch{}, false
+	}
+
 	if !g.matchesPath(request.URL) {
-		return nil, false
+		return routeMatch{}, false
 	}
 
-	var result Route
+	var result routeMatch
+	found := false
+
+	host := requestHost(request)
 
 	for _, r := range g.routes {
-		route, ok := r.findRouteByRequest(request)
+		var match routeMatch
+		var ok bool
+
+		if child, isRoute := r.(*route); isRoute && g.hostMatched {
+			match, ok = child.matchRequestPath(request, host)
+		} else {
+			match, ok = r.matchRequest(request)
+		}
+
 		if !ok {
 			continue
 		}
 
-		if result == nil || result.Priority() < route.Priority() {
-			result = route
+		if !found || match.route.precedes(result.route) {
+			result = match
+			found = true
 		}
 	}
 
-	return result, result != nil
+	return result, found
+}
+
+func (g *routeGroup) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	if request == nil || request.URL == nil {
+		return methods
+	}
+
+	if !g.matchesPath(request.URL) {
+		return methods
+	}
+
+	for _, r := range g.routes {
+		methods = r.allowedMethods(request, methods)
+	}
+
+	return methods
 }
 
 func (g *routeGroup) findRouteByName(name string) (Route, bool) {
@@ -152,6 +426,23 @@ func (g *routeGroup) findRouteByName(name string) (Route, bool) {
 	return nil, false
 }
 
+func (g *routeGroup) collectRoutes(routes []*route) []*route {
+	for _, r := range g.routes {
+		switch child := r.(type) {
+		case *route:
+			routes = append(routes, child)
+		case *routeGroup:
+			routes = child.collectRoutes(routes)
+		case *localizedRoute:
+			routes = child.collectRoutes(routes)
+		case *versionedRouteGroup:
+			routes = child.base.collectRoutes(routes)
+		}
+	}
+
+	return routes
+}
+
 func (g *routeGroup) getPrefix() string {
 	if g.name == "" {
 		return ""
@@ -162,16 +453,22 @@ func (g *routeGroup) getPrefix() string {
 
 func (g *routeGroup) getOptions(options Options) Options {
 	options.DefaultParams = g.defaultParams.toParamsMap().Extend(options.DefaultParams)
+	options.Metadata = g.metadata.Extend(options.Metadata)
+	options.Tags = newTagsList(append(g.tags.toSlice(), options.Tags...)...).toSlice()
 	if g.secure {
 		options.Secure = true
 	}
 	if g.host != "" && options.Host == "" {
 		options.Host = g.host
 	}
+	if g.deprecation != nil && options.Deprecation == nil {
+		options.Deprecation = g.deprecation
+	}
 
 	return options
 }
 
 func (g *routeGroup) matchesPath(requestURL *url.URL) bool {
-	return len(g.forwardRegexp.FindAllStringSubmatch(requestURL.Path, 1)) == 1
+	path, ok := g.basePath.strip(requestURL.Path)
+	return ok && g.forwardRegexp.MatchString(path)
 }
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -92,7 +93,7 @@ func TestRoute_URL(t *testing.T) {
 			},
 			host:    "",
 			secure:  false,
-			result:  "http:///value1/value2/value3",
+			result:  "/value1/value2/value3",
 			missing: "",
 			invalid: "",
 		},
@@ -191,6 +192,50 @@ func TestRoute_URL(t *testing.T) {
 	}
 }
 
+func TestRoute_UncheckedURL(t *testing.T) {
+	cases := []struct {
+		params ParamsMap
+		result string
+		err    error
+	}{
+		{params: ParamsMap{"id": "10"}, result: "/base/users/10"},
+		{params: ParamsMap{"id": "abc"}, result: "/base/users/abc"},
+		{params: ParamsMap{"id": "a b"}, result: "/base/users/a%20b"},
+		{params: ParamsMap{}, err: ErrMissingParam},
+	}
+
+	r, err := NewBuilder().SetBasePath("/base").Build()
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("user", "/users/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, _ := r.FindRouteByName("user")
+
+	for _, c := range cases {
+		result, err := route.UncheckedURL(c.params)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf(`expected error %v but got %v`, c.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+			continue
+		}
+
+		if result.String() != c.result {
+			t.Errorf(`expected result url "%s" but got "%s"`, c.result, result.String())
+		}
+	}
+}
+
 func TestRoute_buildPath(t *testing.T) {
 	cases := []struct {
 		reversePath string
@@ -417,6 +462,7 @@ func TestRoute_ExtractParams(t *testing.T) {
 	cases := []struct {
 		forwardRegexp  *regexp.Regexp
 		requiredParams paramsList
+		defaultParams  paramsValues
 		url            string
 		result         ParamsMap
 		err            string
@@ -459,6 +505,21 @@ func TestRoute_ExtractParams(t *testing.T) {
 			},
 			err: "",
 		},
+		{
+			forwardRegexp:  regexp.MustCompile(`^/path/to/([^\/]+)/([^\/]+)$`),
+			requiredParams: paramsList{"param1", "param2"},
+			defaultParams: paramsValues{
+				"param2":  "default2",
+				"_format": "json",
+			},
+			url: "/path/to/value1/value2",
+			result: ParamsMap{
+				"param1":  "value1",
+				"param2":  "value2",
+				"_format": "json",
+			},
+			err: "",
+		},
 		{
 			forwardRegexp:  regexp.MustCompile(`^/path/to/([^\/]+)/([^\/]+)$`),
 			requiredParams: paramsList{"param1", "param2"},
@@ -486,6 +547,7 @@ func TestRoute_ExtractParams(t *testing.T) {
 		r := &route{
 			forwardRegexp:  c.forwardRegexp,
 			requiredParams: c.requiredParams,
+			defaultParams:  c.defaultParams,
 		}
 
 		var err error
@@ -608,7 +670,7 @@ func TestRoute_findRouteByRequest(t *testing.T) {
 		r := &route{
 			secure:         c.secure,
 			host:           c.host,
-			method:         c.method,
+			methods:        newMethodsList(c.method),
 			forwardRegexp:  c.forwardRegexp,
 			requiredParams: c.requiredParams,
 		}
@@ -758,7 +820,7 @@ func TestRoute_matchesMethod(t *testing.T) {
 
 	for _, c := range cases {
 		r := route{
-			method: c.method,
+			methods: newMethodsList(c.method),
 		}
 
 		req := &http.Request{
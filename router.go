@@ -1,6 +1,7 @@
 package router
 
 import (
+	"io/fs"
 	"net/http"
 )
 
@@ -15,6 +16,10 @@ func (r *router) AddRoute(name string, path string, method string, action Action
 	return r.group.AddRoute(name, path, method, action, options)
 }
 
+func (r *router) AddMethodsRoute(name string, path string, methods []string, action Action, options Options) error {
+	return r.group.AddMethodsRoute(name, path, methods, action, options)
+}
+
 func (r *router) AddDeleteRoute(name string, path string, action Action) error {
 	return r.group.AddDeleteRoute(name, path, action)
 }
@@ -47,10 +52,139 @@ func (r *router) AddRouteGroup(name string, path string, options Options) (Route
 	return r.group.AddRouteGroup(name, path, options)
 }
 
+func (r *router) AddVersionedRouteGroup(name string, path string, options VersionOptions) (VersionedRouteGroup, error) {
+	return r.group.AddVersionedRouteGroup(name, path, options)
+}
+
+func (r *router) AddLocalizedRoute(name string, paths map[string]string, methods []string, action Action, options LocalizedOptions) error {
+	return r.group.AddLocalizedRoute(name, paths, methods, action, options)
+}
+
+func (r *router) Mount(name string, prefix string, handler http.Handler, options MountOptions) error {
+	return r.group.Mount(name, prefix, handler, options)
+}
+
+func (r *router) MountRouter(name string, prefix string, mounted Router, options Options) error {
+	return r.group.MountRouter(name, prefix, mounted, options)
+}
+
+func (r *router) AddStaticRoute(name string, path string, fileSystem http.FileSystem, options StaticOptions) error {
+	return r.group.AddStaticRoute(name, path, fileSystem, options)
+}
+
+func (r *router) AddStaticFSRoute(name string, path string, fileSystem fs.FS, options StaticOptions) error {
+	return r.group.AddStaticFSRoute(name, path, fileSystem, options)
+}
+
+func (r *router) AddRedirectRoute(name string, fromPath string, toRouteName string, status int) error {
+	return r.group.AddRedirectRoute(name, fromPath, toRouteName, status)
+}
+
+func (r *router) AddRouteAlias(routeName string, path string) error {
+	return r.group.AddRouteAlias(routeName, path)
+}
+
+func (r *router) ReplaceRoute(name string, path string, method string, action Action, options Options) error {
+	return r.group.ReplaceRoute(name, path, method, action, options)
+}
+
+func (r *router) ReplaceMethodsRoute(name string, path string, methods []string, action Action, options Options) error {
+	return r.group.ReplaceMethodsRoute(name, path, methods, action, options)
+}
+
+func (r *router) RemoveRoute(name string) error {
+	return r.group.RemoveRoute(name)
+}
+
+func (r *router) RemoveRouteGroup(name string) error {
+	return r.group.RemoveRouteGroup(name)
+}
+
 func (r *router) FindRouteByRequest(request *http.Request) (Route, bool) {
-	return r.group.findRouteByRequest(request)
+	r.group.lock.RLock()
+	defer r.group.lock.RUnlock()
+
+	return r.group.factory.getHostTable().findRouteByRequest(request)
+}
+
+func (r *router) Routes() []Route {
+	r.group.lock.RLock()
+	defer r.group.lock.RUnlock()
+
+	routes := r.group.collectRoutes(nil)
+
+	result := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, route)
+	}
+
+	return result
+}
+
+func (r *router) OrderedRoutes() []Route {
+	r.group.lock.RLock()
+	defer r.group.lock.RUnlock()
+
+	routes := orderRoutes(r.group)
+
+	result := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, route)
+	}
+
+	return result
+}
+
+func (r *router) Match(request *http.Request) (Match, error) {
+	match, err := r.matchRequest(request)
+	if err != nil {
+		return Match{}, err
+	}
+
+	return match.toMatch(), nil
+}
+
+// MatchParams reuses the params buffer, so matching allocates only the
+// submatch indexes of the matched route's path and host patterns with params.
+func (r *router) MatchParams(request *http.Request, params *Params) (Route, error) {
+	match, err := r.matchRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		*params = match.appendParams((*params)[:0])
+	}
+
+	return match.getRoute(), nil
+}
+
+func (r *router) matchRequest(request *http.Request) (routeMatch, error) {
+	if request == nil || request.URL == nil {
+		return routeMatch{}, ErrURLNotProvided
+	}
+
+	r.group.lock.RLock()
+	defer r.group.lock.RUnlock()
+
+	table := r.group.factory.getHostTable()
+
+	match, ok := table.matchRequest(request)
+	if !ok {
+		allowed := table.allowedMethods(request, nil)
+		if len(allowed) > 0 {
+			return routeMatch{}, &MethodNotAllowedError{Allowed: allowed.toSlice()}
+		}
+
+		return routeMatch{}, ErrRouteNotFound
+	}
+
+	return match, nil
 }
 
 func (r *router) FindRouteByName(name string) (Route, bool) {
-	return r.group.findRouteByName(name)
-}
\ No newline at end of file
+	r.group.lock.RLock()
+	defer r.group.lock.RUnlock()
+
+	return r.group.factory.findRouteByName(name)
+}
@@ -0,0 +1,533 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRouter_Match(t *testing.T) {
+	r := New()
+
+	group, err := r.AddRouteGroup("api", "/api/{version}", Options{
+		DefaultParams: ParamsMap{
+			"_format": "json",
+		},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddGetRoute("user", "/users/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("tenant", "/dashboard", http.MethodGet, nil, Options{
+		Host: "{tenant}.domain.com",
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url        string
+		name       string
+		pathParams ParamsMap
+		hostParams ParamsMap
+		defaults   ParamsMap
+		err        error
+	}{
+		{
+			url:  "http://domain.com/api/v1/users/10",
+			name: "api.user",
+			pathParams: ParamsMap{
+				"version": "v1",
+				"id":      "10",
+			},
+			hostParams: ParamsMap{},
+			defaults: ParamsMap{
+				"_format": "json",
+			},
+		},
+		{
+			url:        "http://acme.domain.com/dashboard",
+			name:       "tenant",
+			pathParams: ParamsMap{},
+			hostParams: ParamsMap{
+				"tenant": "acme",
+			},
+			defaults: ParamsMap{},
+		},
+		{
+			url: "http://domain.com/api/v1/users/abc",
+			err: ErrRouteNotFound,
+		},
+		{
+			url: "http://domain.com/dashboard",
+			err: ErrRouteNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		req, err := http.NewRequest(http.MethodGet, c.url, nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		match, err := r.Match(req)
+		if err != c.err {
+			t.Errorf(`expected error %v but got %v`, c.err, err)
+			continue
+		}
+		if c.err != nil {
+			continue
+		}
+
+		if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" but got "%s"`, c.name, match.Route.Name())
+		}
+		if !reflect.DeepEqual(match.PathParams, c.pathParams) {
+			t.Errorf(`expected path params %v but got %v`, c.pathParams, match.PathParams)
+		}
+		if !reflect.DeepEqual(match.HostParams, c.hostParams) {
+			t.Errorf(`expected host params %v but got %v`, c.hostParams, match.HostParams)
+		}
+		if !reflect.DeepEqual(match.Defaults, c.defaults) {
+			t.Errorf(`expected defaults %v but got %v`, c.defaults, match.Defaults)
+		}
+	}
+}
+
+func TestRouter_MatchParams(t *testing.T) {
+	r := New()
+
+	err := r.AddRoute("user", "/users/{id}/posts/{post}", http.MethodGet, nil, Options{
+		DefaultParams: ParamsMap{
+			"post":    "1",
+			"_format": "json",
+		},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/users/10/posts/20", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	params := make(Params, 0, 4)
+	params = append(params, Param{Key: "stale", Value: "value"})
+
+	route, err := r.MatchParams(req, &params)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if route.Name() != "user" {
+		t.Errorf(`expected route "user" but got "%s"`, route.Name())
+	}
+
+	expected := Params{
+		{Key: "id", Value: "10"},
+		{Key: "post", Value: "20"},
+		{Key: "_format", Value: "json", Default: true},
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf(`expected params %v but got %v`, expected, params)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = r.MatchParams(req, &params)
+	})
+	if allocs != 1 && !raceEnabled {
+		t.Errorf(`expected single allocation for path params but got %v`, allocs)
+	}
+
+	err = r.AddRoute("home", "/", http.MethodGet, nil, Options{
+		DefaultParams: ParamsMap{"_format": "html"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		_, _ = r.MatchParams(req, &params)
+	})
+	if allocs != 0 && !raceEnabled {
+		t.Errorf(`expected no allocations for route without params but got %v`, allocs)
+	}
+}
+
+func TestRouter_URL(t *testing.T) {
+	r := New()
+
+	cases := []struct {
+		name     string
+		path     string
+		params   ParamsMap
+		template string
+		result   string
+		url      string
+	}{
+		{
+			name:     "user",
+			path:     "/users/{id:[0-9]+}",
+			params:   ParamsMap{"id": "10"},
+			template: "/users/{id:[0-9]+}",
+			result:   "/users/{id}",
+			url:      "/users/10",
+		},
+		{
+			name:     "archive",
+			path:     "/archive/{year:[0-9]{4}}/{lang:(de|fr)}",
+			params:   ParamsMap{"year": "2020", "lang": "de"},
+			template: "/archive/{year:[0-9]{4}}/{lang:(de|fr)}",
+			result:   "/archive/{year}/{lang}",
+			url:      "/archive/2020/de",
+		},
+		{
+			name:     "search",
+			path:     "/search/{query}",
+			params:   ParamsMap{"query": "a b?c#d%"},
+			template: "/search/{query}",
+			result:   "/search/{query}",
+			url:      "/search/a%20b%3Fc%23d%25",
+		},
+	}
+
+	for _, c := range cases {
+		err := r.AddGetRoute(c.name, c.path, nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		route, ok := r.FindRouteByName(c.name)
+		if !ok {
+			t.Fatalf(`expected route "%s" to exist`, c.name)
+		}
+
+		if route.Path() != c.result {
+			t.Errorf(`expected path "%s" but got "%s"`, c.result, route.Path())
+		}
+		if route.Template() != c.template {
+			t.Errorf(`expected template "%s" but got "%s"`, c.template, route.Template())
+		}
+
+		result, err := route.URL(c.params)
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+			continue
+		}
+
+		if result.EscapedPath() != c.url {
+			t.Errorf(`expected url "%s" but got "%s"`, c.url, result.EscapedPath())
+		}
+
+		req, err := http.NewRequest(http.MethodGet, result.String(), nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		match, err := r.Match(req)
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		} else if match.Route.Name() != c.name || !reflect.DeepEqual(match.PathParams, c.params) {
+			t.Errorf(`expected route "%s" with params %v but got "%s" with %v`, c.name, c.params, match.Route.Name(), match.PathParams)
+		}
+	}
+}
+
+func TestRouter_BasePath(t *testing.T) {
+	cases := []struct {
+		strip   bool
+		request string
+		found   bool
+	}{
+		{strip: false, request: "/users/10", found: true},
+		{strip: false, request: "/app/users/10", found: false},
+		{strip: true, request: "/app/users/10", found: true},
+		{strip: true, request: "/users/10", found: false},
+		{strip: true, request: "/application/users/10", found: false},
+	}
+
+	for _, c := range cases {
+		r, err := NewBuilder().SetBasePath("/app/").SetStripBasePath(c.strip).Build()
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		err = r.AddGetRoute("user", "/users/{id}", nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		route, _ := r.FindRouteByName("user")
+		result, err := route.URL(ParamsMap{"id": "10", FragmentParam: "profile"})
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		} else if result.String() != "/app/users/10#profile" {
+			t.Errorf(`expected url "/app/users/10#profile" but got "%s"`, result.String())
+		}
+
+		req, err := http.NewRequest(http.MethodGet, c.request, nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		match, err := r.Match(req)
+		if c.found != (err == nil) {
+			t.Errorf(`expected found %t for "%s" but got error %v`, c.found, c.request, err)
+		} else if c.found && match.PathParams["id"] != "10" {
+			t.Errorf(`expected param "10" but got "%s"`, match.PathParams["id"])
+		}
+	}
+}
+
+func TestRouter_MatchMethods(t *testing.T) {
+	r := New()
+
+	err := r.AddMethodsRoute("user.update", "/users/{id}", []string{http.MethodPut, http.MethodPatch}, nil, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("user.show", "/users/{id}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddMethodsRoute("ping", "/ping", nil, nil, Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, _ := r.FindRouteByName("user.update")
+	if !reflect.DeepEqual(route.Methods(), []string{http.MethodPatch, http.MethodPut}) {
+		t.Errorf(`expected methods [PATCH PUT] but got %v`, route.Methods())
+	}
+
+	cases := []struct {
+		method  string
+		url     string
+		name    string
+		allowed []string
+	}{
+		{method: http.MethodPut, url: "/users/10", name: "user.update"},
+		{method: http.MethodPatch, url: "/users/10", name: "user.update"},
+		{method: http.MethodGet, url: "/users/10", name: "user.show"},
+		{method: http.MethodDelete, url: "/users/10", allowed: []string{http.MethodGet, http.MethodPatch, http.MethodPut}},
+		{method: http.MethodDelete, url: "/ping", name: "ping"},
+	}
+
+	for _, c := range cases {
+		req, err := http.NewRequest(c.method, c.url, nil)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		match, err := r.Match(req)
+		if c.allowed != nil {
+			methodErr, ok := err.(*MethodNotAllowedError)
+			if !ok {
+				t.Errorf(`expected method not allowed error but got %v`, err)
+			} else if !reflect.DeepEqual(methodErr.Allowed, c.allowed) {
+				t.Errorf(`expected allowed methods %v but got %v`, c.allowed, methodErr.Allowed)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		} else if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" but got "%s"`, c.name, match.Route.Name())
+		}
+	}
+}
+
+func TestRouter_RemoveRoute(t *testing.T) {
+	r := New()
+
+	group, err := r.AddRouteGroup("api", "/api", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	users, err := group.AddRouteGroup("users", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, name := range []string{"list", "beta"} {
+		err = users.AddGetRoute(name, "/"+name, name)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+	}
+
+	err = r.RemoveRoute("api.users.beta")
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+	if _, ok := r.FindRouteByName("api.users.beta"); ok {
+		t.Error(`expected route "api.users.beta" to be removed`)
+	}
+
+	err = r.RemoveRoute("api.users.beta")
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf(`expected route not found error but got %v`, err)
+	}
+
+	err = group.ReplaceRoute("users.list", "/all", http.MethodGet, "replaced", Options{})
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/users/all", nil)
+	route, ok := r.FindRouteByRequest(req)
+	if !ok || route.Name() != "api.users.list" || route.Action() != "replaced" {
+		t.Errorf(`expected replaced route "api.users.list" but got %v`, route)
+	}
+
+	err = r.RemoveRouteGroup("api.users")
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+	if _, ok := r.FindRouteByName("api.users.list"); ok {
+		t.Error(`expected route "api.users.list" to be removed with its group`)
+	}
+}
+
+func TestRouter_FindRouteByName(t *testing.T) {
+	r := New()
+
+	users, err := r.AddRouteGroup("users", "/users", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id}", "show")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("users.show", "/people/{id}", nil)
+	if !errors.Is(err, ErrDuplicateRoute) {
+		t.Errorf(`expected duplicate route error but got %v`, err)
+	}
+
+	err = users.ReplaceRoute("show", "/{id:[0-9]+}", http.MethodGet, "replaced", Options{})
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByName("users.show")
+	if !ok || route.Action() != "replaced" {
+		t.Errorf(`expected replaced route "users.show" but got %v`, route)
+	}
+
+	err = r.RemoveRouteGroup("users")
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddGetRoute("users.show", "/people/{id}", "people")
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	}
+
+	route, ok = r.FindRouteByName("users.show")
+	if !ok || route.Action() != "people" {
+		t.Errorf(`expected route "users.show" with action "people" but got %v`, route)
+	}
+}
+
+func TestRouter_ConcurrentModification(t *testing.T) {
+	r := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				name := fmt.Sprintf("route%d_%d", i, j)
+				_ = r.AddGetRoute(name, "/"+name, nil)
+
+				req, _ := http.NewRequest(http.MethodGet, "/"+name, nil)
+				_, _ = r.Match(req)
+				_, _ = r.FindRouteByName(name)
+
+				_ = r.RemoveRoute(name)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRouter_Metadata(t *testing.T) {
+	r := New()
+
+	group, err := r.AddRouteGroup("admin", "/admin", Options{
+		Metadata: Metadata{
+			"scope": "admin",
+			"team":  "platform",
+		},
+		Tags: []string{"internal"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = group.AddRoute("users", "/users", http.MethodGet, nil, Options{
+		Metadata: Metadata{
+			"team":       "identity",
+			"rate_limit": 100,
+		},
+		Tags: []string{"users", "internal"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users", nil)
+	route, ok := r.FindRouteByRequest(req)
+	if !ok {
+		t.Fatal(`expected route to be found`)
+	}
+
+	expected := Metadata{
+		"scope":      "admin",
+		"team":       "identity",
+		"rate_limit": 100,
+	}
+	if !reflect.DeepEqual(route.Metadata(), expected) {
+		t.Errorf(`expected metadata %v but got %v`, expected, route.Metadata())
+	}
+
+	if limit, ok := route.Metadata().Int("rate_limit"); !ok || limit != 100 {
+		t.Errorf(`expected rate limit 100 but got %d`, limit)
+	}
+
+	if !reflect.DeepEqual(route.Tags(), []string{"internal", "users"}) {
+		t.Errorf(`expected tags [internal users] but got %v`, route.Tags())
+	}
+	if !route.HasTag("users") || route.HasTag("public") {
+		t.Errorf(`unexpected tags %v`, route.Tags())
+	}
+
+	routes := r.Routes()
+	if len(routes) != 1 || routes[0].Name() != "admin.users" {
+		t.Errorf(`expected single route "admin.users" but got %v`, routes)
+	}
+}
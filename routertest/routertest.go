@@ -0,0 +1,175 @@
+package routertest
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ompluscator/router"
+)
+
+var update = flag.Bool("routertest.update", false, "update routertest golden files")
+
+type MatchCase struct {
+	Method   string
+	URL      string
+	Name     string
+	Params   router.ParamsMap
+	NotFound bool
+}
+
+type URLCase struct {
+	Name          string
+	Params        router.ParamsMap
+	ReferenceType router.ReferenceType
+	URL           string
+}
+
+type RoundTripCase struct {
+	Name   string
+	Method string
+	Params router.ParamsMap
+}
+
+func AssertMatches(t testing.TB, r router.Router, cases []MatchCase) {
+	t.Helper()
+
+	for _, c := range cases {
+		method := c.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		match, err := r.Match(httptest.NewRequest(method, c.URL, nil))
+		if c.NotFound {
+			if err == nil {
+				t.Errorf(`expected %s "%s" not to be matched but got route "%s"`, method, c.URL, match.Route.Name())
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`expected %s "%s" to be matched but got %v`, method, c.URL, err)
+			continue
+		}
+
+		if match.Route.Name() != c.Name {
+			t.Errorf(`expected %s "%s" to match route "%s" but got "%s"`, method, c.URL, c.Name, match.Route.Name())
+		}
+
+		if c.Params != nil && !reflect.DeepEqual(match.Params(), c.Params) {
+			t.Errorf(`expected %s "%s" to have params %v but got %v`, method, c.URL, c.Params, match.Params())
+		}
+	}
+}
+
+func AssertURLs(t testing.TB, r router.Router, cases []URLCase) {
+	t.Helper()
+
+	for _, c := range cases {
+		route, ok := r.FindRouteByName(c.Name)
+		if !ok {
+			t.Errorf(`expected route "%s" to exist`, c.Name)
+			continue
+		}
+
+		result, err := route.GenerateURL(c.Params, c.ReferenceType)
+		if err != nil {
+			t.Errorf(`expected route "%s" to generate "%s" but got %v`, c.Name, c.URL, err)
+			continue
+		}
+
+		if result.String() != c.URL {
+			t.Errorf(`expected route "%s" to generate "%s" but got "%s"`, c.Name, c.URL, result.String())
+		}
+	}
+}
+
+func AssertRoundTrips(t testing.TB, r router.Router, cases []RoundTripCase) {
+	t.Helper()
+
+	for _, c := range cases {
+		route, ok := r.FindRouteByName(c.Name)
+		if !ok {
+			t.Errorf(`expected route "%s" to exist`, c.Name)
+			continue
+		}
+
+		result, err := route.URL(c.Params)
+		if err != nil {
+			t.Errorf(`expected route "%s" to generate url but got %v`, c.Name, err)
+			continue
+		}
+
+		method := c.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		match, err := r.Match(httptest.NewRequest(method, result.String(), nil))
+		if err != nil {
+			t.Errorf(`expected %s "%s" generated by route "%s" to be matched but got %v`, method, result, c.Name, err)
+			continue
+		}
+
+		if match.Route.Name() != c.Name {
+			t.Errorf(`expected %s "%s" to match route "%s" but got "%s"`, method, result, c.Name, match.Route.Name())
+		}
+
+		params := match.Params()
+		for key, value := range c.Params {
+			if key == router.FragmentParam {
+				continue
+			}
+
+			if params[key] != value {
+				t.Errorf(`expected %s "%s" to have param "%s" with value "%s" but got "%s"`, method, result, key, value, params[key])
+			}
+		}
+	}
+}
+
+func AssertGolden(t testing.TB, r router.Router, path string) {
+	t.Helper()
+
+	actual, err := Snapshot(r)
+	if err != nil {
+		t.Fatalf(`not expected error while taking route table snapshot but got %v`, err)
+	}
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, actual, 0644)
+		}
+		if err != nil {
+			t.Fatalf(`not expected error while updating golden file "%s" but got %v`, path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf(`not expected error while reading golden file "%s" but got %v (run with -routertest.update to create it)`, path, err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("route table does not match golden file \"%s\"\n--- expected\n%s\n--- actual\n%s", path, expected, actual)
+	}
+}
+
+// Snapshot renders the route table exported by router.ExportRouteTable, so
+// every serialized field of every route is compared.
+func Snapshot(r router.Router) ([]byte, error) {
+	result, err := router.MarshalRouteTable(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, '\n'), nil
+}
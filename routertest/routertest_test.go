@@ -0,0 +1,260 @@
+package routertest
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ompluscator/router"
+)
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertMatches(t *testing.T) {
+	cases := []struct {
+		match  MatchCase
+		failed bool
+	}{
+		{match: MatchCase{URL: "/users/10", Name: "users.show", Params: router.ParamsMap{"id": "10"}}},
+		{match: MatchCase{Method: http.MethodPut, URL: "/users/10", Name: "users.update"}},
+		{match: MatchCase{URL: "/pages/about", Name: "page", Params: router.ParamsMap{"slug": "about"}}},
+		{match: MatchCase{URL: "/users/abc", NotFound: true}},
+		{match: MatchCase{URL: "/users/10", Name: "users.update"}, failed: true},
+		{match: MatchCase{URL: "/users/10", Name: "users.show", Params: router.ParamsMap{"id": "11"}}, failed: true},
+		{match: MatchCase{URL: "/users/10", NotFound: true}, failed: true},
+		{match: MatchCase{Method: http.MethodPost, URL: "/users/10", Name: "users.show"}, failed: true},
+	}
+
+	r := router.New()
+
+	users, err := r.AddRouteGroup("users", "/users", router.Options{
+		Tags: []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("list", "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddPutRoute("update", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("page", "/pages/{slug}", "", nil, router.Options{
+		DefaultParams: router.ParamsMap{"slug": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, c := range cases {
+		result := &recorder{TB: t}
+		AssertMatches(result, r, []MatchCase{c.match})
+
+		if failed := len(result.errors) > 0; failed != c.failed {
+			t.Errorf(`expected failed to be %t for %v but got %v`, c.failed, c.match, result.errors)
+		}
+	}
+}
+
+func TestAssertURLs(t *testing.T) {
+	cases := []struct {
+		url    URLCase
+		failed bool
+	}{
+		{url: URLCase{Name: "users.show", Params: router.ParamsMap{"id": "10"}, URL: "/users/10"}},
+		{url: URLCase{Name: "page", URL: "/pages/home"}},
+		{url: URLCase{Name: "users.show", Params: router.ParamsMap{"id": "10"}, URL: "/users/11"}, failed: true},
+		{url: URLCase{Name: "users.show", Params: router.ParamsMap{"id": "abc"}, URL: "/users/abc"}, failed: true},
+		{url: URLCase{Name: "users.missing", URL: "/users"}, failed: true},
+	}
+
+	r := router.New()
+
+	users, err := r.AddRouteGroup("users", "/users", router.Options{
+		Tags: []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("list", "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddPutRoute("update", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("page", "/pages/{slug}", "", nil, router.Options{
+		DefaultParams: router.ParamsMap{"slug": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, c := range cases {
+		result := &recorder{TB: t}
+		AssertURLs(result, r, []URLCase{c.url})
+
+		if failed := len(result.errors) > 0; failed != c.failed {
+			t.Errorf(`expected failed to be %t for %v but got %v`, c.failed, c.url, result.errors)
+		}
+	}
+}
+
+func TestAssertRoundTrips(t *testing.T) {
+	cases := []struct {
+		roundTrip RoundTripCase
+		failed    bool
+	}{
+		{roundTrip: RoundTripCase{Name: "users.show", Params: router.ParamsMap{"id": "10"}}},
+		{roundTrip: RoundTripCase{Name: "users.update", Method: http.MethodPut, Params: router.ParamsMap{"id": "10"}}},
+		{roundTrip: RoundTripCase{Name: "page", Params: router.ParamsMap{"slug": "a b"}}},
+		{roundTrip: RoundTripCase{Name: "users.update", Params: router.ParamsMap{"id": "10"}}, failed: true},
+		{roundTrip: RoundTripCase{Name: "users.show"}, failed: true},
+	}
+
+	r := router.New()
+
+	users, err := r.AddRouteGroup("users", "/users", router.Options{
+		Tags: []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("list", "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddPutRoute("update", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("page", "/pages/{slug}", "", nil, router.Options{
+		DefaultParams: router.ParamsMap{"slug": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, c := range cases {
+		result := &recorder{TB: t}
+		AssertRoundTrips(result, r, []RoundTripCase{c.roundTrip})
+
+		if failed := len(result.errors) > 0; failed != c.failed {
+			t.Errorf(`expected failed to be %t for %v but got %v`, c.failed, c.roundTrip, result.errors)
+		}
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	r := router.New()
+
+	users, err := r.AddRouteGroup("users", "/users", router.Options{
+		Tags: []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("list", "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddPutRoute("update", "/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.AddRoute("page", "/pages/{slug}", "", nil, router.Options{
+		DefaultParams: router.ParamsMap{"slug": "home"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	AssertGolden(t, r, filepath.Join("testdata", "routes.golden"))
+
+	if *update {
+		return
+	}
+
+	err = users.ReplaceRoute("show", "/{id:[0-9]+}", http.MethodGet, nil, router.Options{
+		Priority: 5,
+		Tags:     []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	result := &recorder{TB: t}
+	AssertGolden(result, r, filepath.Join("testdata", "routes.golden"))
+
+	if len(result.errors) == 0 {
+		t.Error(`expected golden file mismatch for changed priority but got none`)
+	}
+
+	err = users.ReplaceRoute("show", "/{id:[0-9]+}", http.MethodGet, nil, router.Options{
+		Tags: []string{"users"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = r.RemoveRoute("page")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	result = &recorder{TB: t}
+	AssertGolden(result, r, filepath.Join("testdata", "routes.golden"))
+
+	if len(result.errors) == 0 {
+		t.Error(`expected golden file mismatch but got none`)
+	}
+}
@@ -0,0 +1,335 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	pathLib "path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	StaticFileParam        = "filepath"
+	StaticFingerprintParam = "v"
+)
+
+type StaticOptions struct {
+	Options
+	Index           string
+	ListDirectories bool
+	Precompressed   bool
+	Fingerprint     bool
+}
+
+type staticHandler struct {
+	fileSystem http.FileSystem
+	route      *route
+	options    StaticOptions
+	hashes     sync.Map
+}
+
+type staticHash struct {
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+type staticEncoding struct {
+	name      string
+	extension string
+}
+
+var staticEncodings = []staticEncoding{
+	{name: "br", extension: ".br"},
+	{name: "gzip", extension: ".gz"},
+}
+
+var _ http.Handler = &staticHandler{}
+
+// emptyFileSystem is served by static routes registered without a file
+// system, so they respond with 404.
+type emptyFileSystem struct{}
+
+var _ http.FileSystem = emptyFileSystem{}
+
+func (g *routeGroup) AddStaticRoute(name string, path string, fileSystem http.FileSystem, options StaticOptions) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.addStaticRoute(name, path, fileSystem, options)
+}
+
+func (g *routeGroup) AddStaticFSRoute(name string, path string, fileSystem fs.FS, options StaticOptions) error {
+	return g.AddStaticRoute(name, path, http.FS(fileSystem), options)
+}
+
+func (g *routeGroup) addStaticRoute(name string, path string, fileSystem http.FileSystem, options StaticOptions) error {
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	route, err := g.createStaticRoute(finalName, path, fileSystem, options)
+	if err != nil {
+		return err
+	}
+
+	return g.appendRoute(route)
+}
+
+func (g *routeGroup) createStaticRoute(finalName string, path string, fileSystem http.FileSystem, options StaticOptions) (*route, error) {
+	if fileSystem == nil {
+		fileSystem = emptyFileSystem{}
+	}
+
+	staticPath := pathLib.Join(path, fmt.Sprintf("{%s:.*}", StaticFileParam))
+	methods := []string{http.MethodGet, http.MethodHead}
+
+	route, err := g.createRoute(finalName, staticPath, methods, nil, options.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := &staticHandler{
+		fileSystem: fileSystem,
+		route:      route,
+		options:    options,
+	}
+
+	route.action = handler
+	if options.Fingerprint {
+		route.urlModifier = handler.fingerprint
+	}
+
+	return route, nil
+}
+
+func (emptyFileSystem) Open(name string) (http.File, error) {
+	return nil, os.ErrNotExist
+}
+
+func (h *staticHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	params, err := h.route.ExtractPathParams(request)
+	if err != nil {
+		http.NotFound(writer, request)
+		return
+	}
+
+	name := pathLib.Clean("/" + params[StaticFileParam])
+
+	file, err := h.fileSystem.Open(name)
+	if err != nil {
+		http.NotFound(writer, request)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		http.NotFound(writer, request)
+		return
+	}
+
+	if info.IsDir() {
+		h.serveDirectory(writer, request, name, file)
+		return
+	}
+
+	h.serveFile(writer, request, name, file, info)
+}
+
+func (h *staticHandler) serveDirectory(writer http.ResponseWriter, request *http.Request, name string, directory http.File) {
+	if h.options.Index != "" {
+		index := pathLib.Join(name, h.options.Index)
+
+		file, err := h.fileSystem.Open(index)
+		if err == nil {
+			defer file.Close()
+
+			info, err := file.Stat()
+			if err == nil && !info.IsDir() {
+				h.serveFile(writer, request, index, file, info)
+				return
+			}
+		}
+	}
+
+	if !h.options.ListDirectories {
+		http.NotFound(writer, request)
+		return
+	}
+
+	entries, err := directory.Readdir(-1)
+	if err != nil {
+		http.Error(writer, "error reading directory", http.StatusInternalServerError)
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(writer, "<pre>\n")
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += "/"
+		}
+
+		link := url.URL{Path: entryName}
+		fmt.Fprintf(writer, "<a href=\"%s\">%s</a>\n", link.String(), html.EscapeString(entryName))
+	}
+	fmt.Fprintf(writer, "</pre>\n")
+}
+
+func (h *staticHandler) serveFile(writer http.ResponseWriter, request *http.Request, name string, file http.File, info os.FileInfo) {
+	contentType := mime.TypeByExtension(pathLib.Ext(name))
+
+	if h.options.Precompressed {
+		writer.Header().Add("Vary", "Accept-Encoding")
+
+		accepted := request.Header.Get("Accept-Encoding")
+		for _, encoding := range staticEncodings {
+			if !acceptsEncoding(accepted, encoding.name) {
+				continue
+			}
+
+			compressed, err := h.fileSystem.Open(name + encoding.extension)
+			if err != nil {
+				continue
+			}
+			defer compressed.Close()
+
+			compressedInfo, err := compressed.Stat()
+			if err != nil || compressedInfo.IsDir() {
+				continue
+			}
+
+			if contentType != "" {
+				writer.Header().Set("Content-Type", contentType)
+			}
+			writer.Header().Set("Content-Encoding", encoding.name)
+			h.setETag(writer, name+encoding.extension, compressed, compressedInfo)
+
+			http.ServeContent(writer, request, name, compressedInfo.ModTime(), compressed)
+			return
+		}
+	}
+
+	if contentType != "" {
+		writer.Header().Set("Content-Type", contentType)
+	}
+	h.setETag(writer, name, file, info)
+
+	http.ServeContent(writer, request, name, info.ModTime(), file)
+}
+
+// setETag uses the content hash, as modification times are not reliable
+// for embedded files.
+func (h *staticHandler) setETag(writer http.ResponseWriter, name string, file http.File, info os.FileInfo) {
+	hash, err := h.hash(name, file, info)
+	if err != nil {
+		return
+	}
+
+	writer.Header().Set("ETag", fmt.Sprintf(`"%s"`, hash))
+}
+
+func (h *staticHandler) hash(name string, file http.File, info os.FileInfo) (string, error) {
+	if cached, ok := h.hashes.Load(name); ok {
+		entry := cached.(staticHash)
+		if entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			return entry.value, nil
+		}
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	value := hex.EncodeToString(hasher.Sum(nil))[:16]
+	h.hashes.Store(name, staticHash{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		value:   value,
+	})
+
+	return value, nil
+}
+
+func (h *staticHandler) fingerprint(result *url.URL, params ParamsMap) error {
+	name := pathLib.Clean("/" + params[StaticFileParam])
+
+	file, err := h.fileSystem.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return nil
+	}
+
+	hash, err := h.hash(name, file, info)
+	if err != nil {
+		return nil
+	}
+
+	result.RawQuery = url.Values{StaticFingerprintParam: {hash}}.Encode()
+
+	return nil
+}
+
+// acceptsEncoding checks the Accept-Encoding header, where a zero quality
+// value rejects the encoding and the wildcard covers unlisted ones.
+func acceptsEncoding(header string, encoding string) bool {
+	wildcard := false
+
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		token := strings.TrimSpace(params[0])
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if len(param) < 2 || !strings.EqualFold(param[:2], "q=") {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(param[2:], 64)
+			if err != nil {
+				value = 0
+			}
+			quality = value
+		}
+
+		if strings.EqualFold(token, encoding) {
+			return quality > 0
+		}
+
+		if token == "*" {
+			wildcard = quality > 0
+		}
+	}
+
+	return wildcard
+}
@@ -0,0 +1,129 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRouteGroup_AddStaticRoute(t *testing.T) {
+	modTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	files := fstest.MapFS{
+		"app.js":          {Data: []byte("console.log(1)"), ModTime: modTime},
+		"app.js.gz":       {Data: []byte("gzipped"), ModTime: modTime},
+		"docs/index.html": {Data: []byte("<h1>docs</h1>"), ModTime: modTime},
+		"images/logo.svg": {Data: []byte("<svg/>"), ModTime: modTime},
+	}
+
+	r := New()
+
+	err := r.AddStaticRoute("assets", "/assets", http.FS(files), StaticOptions{
+		Index:         "index.html",
+		Precompressed: true,
+		Fingerprint:   true,
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url      string
+		encoding string
+		status   int
+		body     string
+		header   string
+		value    string
+	}{
+		{url: "/assets/app.js", status: http.StatusOK, body: "console.log(1)", header: "Content-Type", value: "text/javascript; charset=utf-8"},
+		{url: "/assets/app.js", encoding: "gzip, deflate", status: http.StatusOK, body: "gzipped", header: "Content-Encoding", value: "gzip"},
+		{url: "/assets/app.js", encoding: "gzip;q=0, deflate", status: http.StatusOK, body: "console.log(1)", header: "Content-Encoding", value: ""},
+		{url: "/assets/app.js", encoding: "br;q=0.5, *;q=0.1", status: http.StatusOK, body: "gzipped", header: "Content-Encoding", value: "gzip"},
+		{url: "/assets/docs/", status: http.StatusOK, body: "<h1>docs</h1>"},
+		{url: "/assets/images/", status: http.StatusNotFound},
+		{url: "/assets/../static_test.go", status: http.StatusNotFound},
+		{url: "/assets/missing.css", status: http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+		if c.encoding != "" {
+			req.Header.Set("Accept-Encoding", c.encoding)
+		}
+
+		route, ok := r.FindRouteByRequest(req)
+		if !ok {
+			t.Errorf(`expected "%s" to be matched`, c.url)
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+		if recorder.Code != c.status {
+			t.Errorf(`expected status %d for "%s" but got %d`, c.status, c.url, recorder.Code)
+		}
+		if c.body != "" && recorder.Body.String() != c.body {
+			t.Errorf(`expected body "%s" for "%s" but got "%s"`, c.body, c.url, recorder.Body.String())
+		}
+		if c.header != "" && recorder.Header().Get(c.header) != c.value {
+			t.Errorf(`expected header %s "%s" but got "%s"`, c.header, c.value, recorder.Header().Get(c.header))
+		}
+	}
+
+	route, _ := r.FindRouteByName("assets")
+
+	result, err := route.URL(ParamsMap{StaticFileParam: "app.js"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+	if !strings.HasPrefix(result.String(), "/assets/app.js?v=") {
+		t.Errorf(`expected fingerprinted url but got "%s"`, result.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, result.String(), nil)
+	recorder := httptest.NewRecorder()
+	route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+	etag := recorder.Header().Get("ETag")
+	if etag != fmt.Sprintf(`"%s"`, result.Query().Get(StaticFingerprintParam)) {
+		t.Fatalf(`expected ETag with content hash but got "%s"`, etag)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, result.String(), nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf(`expected status %d but got %d`, http.StatusNotModified, recorder.Code)
+	}
+}
+
+func TestRouteGroup_AddStaticFSRoute(t *testing.T) {
+	r := New()
+
+	err := r.AddStaticFSRoute("assets", "/assets", fstest.MapFS{
+		"app.css": {Data: []byte("body{}")},
+	}, StaticOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/app.css", nil)
+
+	route, ok := r.FindRouteByRequest(req)
+	if !ok {
+		t.Fatalf(`expected "/assets/app.css" to be matched`)
+	}
+
+	recorder := httptest.NewRecorder()
+	route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "body{}" {
+		t.Errorf(`expected file content but got %d "%s"`, recorder.Code, recorder.Body.String())
+	}
+}
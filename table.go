@@ -0,0 +1,394 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const RouteTableVersion = 1
+
+// Kinds of route definitions, which are restored with their own behaviour.
+const (
+	RouteKindMount     = "mount"
+	RouteKindStatic    = "static"
+	RouteKindRedirect  = "redirect"
+	RouteKindAlias     = "alias"
+	RouteKindLocalized = "localized"
+)
+
+// RouteTable is a flat, serializable description of every route registered
+// in a router. Groups are resolved into the routes they contain, so restoring
+// a table registers each of them directly on the root. Routers with versioned
+// groups can not be exported, as the version selection and the inherited
+// routes are not described by the table.
+type RouteTable struct {
+	Version                 int               `json:"version"`
+	Secure                  bool              `json:"secure,omitempty"`
+	Host                    string            `json:"host,omitempty"`
+	BasePath                string            `json:"basePath,omitempty"`
+	StripBasePath           bool              `json:"stripBasePath,omitempty"`
+	DefaultParamRequirement string            `json:"defaultParamRequirement"`
+	CollectErrors           bool              `json:"collectErrors,omitempty"`
+	Routes                  []RouteDefinition `json:"routes"`
+}
+
+// RouteDefinition describes a single route. Aliases are named after their
+// target, static routes keep their file param in the template and localized
+// routes list their templates by locale instead of a single one.
+type RouteDefinition struct {
+	Name           string            `json:"name"`
+	Kind           string            `json:"kind,omitempty"`
+	Template       string            `json:"template,omitempty"`
+	Locales        map[string]string `json:"locales,omitempty"`
+	DefaultLocale  string            `json:"defaultLocale,omitempty"`
+	Methods        []string          `json:"methods,omitempty"`
+	Host           string            `json:"host,omitempty"`
+	Secure         bool              `json:"secure,omitempty"`
+	Priority       int               `json:"priority,omitempty"`
+	DefaultParams  ParamsMap         `json:"defaultParams,omitempty"`
+	Metadata       Metadata          `json:"metadata,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Deprecation    *Deprecation      `json:"deprecation,omitempty"`
+	Target         string            `json:"target,omitempty"`
+	Status         int               `json:"status,omitempty"`
+	PreservePrefix bool              `json:"preservePrefix,omitempty"`
+	Static         *StaticDefinition `json:"static,omitempty"`
+}
+
+type StaticDefinition struct {
+	Index           string `json:"index,omitempty"`
+	ListDirectories bool   `json:"listDirectories,omitempty"`
+	Precompressed   bool   `json:"precompressed,omitempty"`
+	Fingerprint     bool   `json:"fingerprint,omitempty"`
+}
+
+type tableEntry struct {
+	definition RouteDefinition
+	sequence   uint64
+}
+
+func ExportRouteTable(r Router) (RouteTable, error) {
+	source, ok := r.(*router)
+	if !ok {
+		return RouteTable{}, errors.New("route table can be exported only from routers created by the builder")
+	}
+
+	source.group.lock.RLock()
+	defer source.group.lock.RUnlock()
+
+	entries, err := source.group.exportRoutes(nil)
+	if err != nil {
+		return RouteTable{}, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].sequence < entries[j].sequence
+	})
+
+	factory := source.group.factory
+
+	table := RouteTable{
+		Version:                 RouteTableVersion,
+		Secure:                  source.group.secure,
+		Host:                    source.group.host,
+		BasePath:                factory.basePath.value,
+		StripBasePath:           factory.basePath.stripPrefix,
+		DefaultParamRequirement: factory.requirement.String(),
+		CollectErrors:           factory.collectErrors,
+		Routes:                  make([]RouteDefinition, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		table.Routes = append(table.Routes, entry.definition)
+	}
+
+	return table, nil
+}
+
+func (g *routeGroup) exportRoutes(entries []tableEntry) ([]tableEntry, error) {
+	for _, r := range g.routes {
+		switch child := r.(type) {
+		case *route:
+			entries = append(entries, tableEntry{
+				definition: newRouteDefinition(child),
+				sequence:   child.order.sequence,
+			})
+		case *routeGroup:
+			var err error
+			entries, err = child.exportRoutes(entries)
+			if err != nil {
+				return nil, err
+			}
+		case *localizedRoute:
+			defaultRoute := child.locales[child.defaultLocale]
+
+			definition := newRouteDefinition(defaultRoute)
+			definition.Name = child.name
+			definition.Kind = RouteKindLocalized
+			definition.Template = ""
+			definition.DefaultLocale = child.defaultLocale
+			definition.Locales = make(map[string]string, len(child.locales))
+
+			delete(definition.DefaultParams, LocaleParam)
+			if len(definition.DefaultParams) == 0 {
+				definition.DefaultParams = nil
+			}
+
+			sequence := defaultRoute.order.sequence
+			for locale, localeRoute := range child.locales {
+				definition.Locales[locale] = localeRoute.Template()
+				if localeRoute.order.sequence < sequence {
+					sequence = localeRoute.order.sequence
+				}
+			}
+
+			entries = append(entries, tableEntry{
+				definition: definition,
+				sequence:   sequence,
+			})
+		case *routeAlias:
+			entries = append(entries, tableEntry{
+				definition: RouteDefinition{
+					Name:     child.target,
+					Kind:     RouteKindAlias,
+					Template: child.route.Template(),
+				},
+				sequence: child.route.order.sequence,
+			})
+		case *versionedRouteGroup:
+			return nil, &RouteError{Route: child.base.name, Err: errors.New("versioned route group can not be exported")}
+		}
+	}
+
+	return entries, nil
+}
+
+func newRouteDefinition(route *route) RouteDefinition {
+	definition := RouteDefinition{
+		Name:        route.name,
+		Template:    route.Template(),
+		Methods:     route.Methods(),
+		Host:        route.host,
+		Secure:      route.secure,
+		Priority:    route.priority,
+		Tags:        route.Tags(),
+		Deprecation: route.deprecation.copy(),
+	}
+
+	if len(route.defaultParams) > 0 {
+		definition.DefaultParams = route.DefaultParams()
+	}
+
+	if len(route.metadata) > 0 {
+		definition.Metadata = route.Metadata()
+	}
+
+	switch handler := route.action.(type) {
+	case *mountHandler:
+		definition.Kind = RouteKindMount
+		definition.PreservePrefix = handler.preservePrefix
+	case *staticHandler:
+		definition.Kind = RouteKindStatic
+		definition.Methods = nil
+		definition.Static = &StaticDefinition{
+			Index:           handler.options.Index,
+			ListDirectories: handler.options.ListDirectories,
+			Precompressed:   handler.options.Precompressed,
+			Fingerprint:     handler.options.Fingerprint,
+		}
+	case *redirectHandler:
+		definition.Kind = RouteKindRedirect
+		definition.Target = handler.target
+		definition.Status = handler.status
+	}
+
+	return definition
+}
+
+func MarshalRouteTable(r Router) ([]byte, error) {
+	table, err := ExportRouteTable(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(table, "", "  ")
+}
+
+func UnmarshalRouteTable(data []byte) (RouteTable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var table RouteTable
+	if err := decoder.Decode(&table); err != nil {
+		return RouteTable{}, fmt.Errorf(`error while decoding route table: %w`, err)
+	}
+
+	if table.Version != RouteTableVersion {
+		return RouteTable{}, fmt.Errorf(`unsupported route table version %d`, table.Version)
+	}
+
+	for _, definition := range table.Routes {
+		for key, value := range definition.Metadata {
+			definition.Metadata[key] = decodeMetadataValue(value)
+		}
+	}
+
+	return table, nil
+}
+
+func decodeMetadataValue(value interface{}) interface{} {
+	switch result := value.(type) {
+	case []interface{}:
+		for index, item := range result {
+			result[index] = decodeMetadataValue(item)
+		}
+		return result
+	case map[string]interface{}:
+		for key, item := range result {
+			result[key] = decodeMetadataValue(item)
+		}
+		return result
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+
+	if result, err := number.Int64(); err == nil {
+		return int(result)
+	}
+
+	if result, err := number.Float64(); err == nil {
+		return result
+	}
+
+	return number.String()
+}
+
+// Build restores a router from the table, binding each route to the action
+// registered under its name. Mounts take an http.Handler and static routes an
+// http.FileSystem as their action. Routes without an action are restored
+// with nil, while mounts and static routes without one respond with 404.
+// Build registers every route again, so it compiles the same regexps as
+// registering the routes directly and does not shorten startup.
+func (t RouteTable) Build(actions map[string]Action) (Router, error) {
+	builder := NewBuilder().
+		SetSecure(t.Secure).
+		SetHost(t.Host).
+		SetBasePath(t.BasePath).
+		SetStripBasePath(t.StripBasePath).
+		SetCollectErrors(t.CollectErrors)
+
+	if t.DefaultParamRequirement != "" {
+		builder.SetDefaultParamRequirement(t.DefaultParamRequirement)
+	}
+
+	result, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	root := result.(*router).group
+
+	root.lock.Lock()
+	defer root.lock.Unlock()
+
+	for _, definition := range t.Routes {
+		err := root.restoreRoute(definition, actions[definition.Name])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Restore registers the route described by the definition on the root of
+// the router, the same way as Build does for each route of a table.
+func (d RouteDefinition) Restore(r Router, action Action) error {
+	target, ok := r.(*router)
+	if !ok {
+		return &RouteError{Route: d.Name, Err: errors.New("route can be restored only into routers created by the builder")}
+	}
+
+	target.group.lock.Lock()
+	defer target.group.lock.Unlock()
+
+	return target.group.restoreRoute(d, action)
+}
+
+func (g *routeGroup) restoreRoute(definition RouteDefinition, action Action) error {
+	options := Options{
+		Priority:      definition.Priority,
+		Secure:        definition.Secure,
+		Host:          definition.Host,
+		DefaultParams: definition.DefaultParams,
+		Metadata:      definition.Metadata,
+		Tags:          definition.Tags,
+		Deprecation:   definition.Deprecation,
+	}
+
+	switch definition.Kind {
+	case "":
+		return g.addRoute(definition.Name, definition.Template, definition.Methods, action, options)
+	case RouteKindMount:
+		handler, ok := action.(http.Handler)
+		if action != nil && !ok {
+			return &RouteError{Route: definition.Name, Err: errors.New("mount action is not an http.Handler")}
+		}
+
+		return g.mount(definition.Name, definition.Template, handler, MountOptions{
+			Options:        options,
+			PreservePrefix: definition.PreservePrefix,
+		})
+	case RouteKindStatic:
+		fileSystem, ok := action.(http.FileSystem)
+		if action != nil && !ok {
+			return &RouteError{Route: definition.Name, Err: errors.New("static action is not an http.FileSystem")}
+		}
+
+		staticOptions := StaticOptions{
+			Options: options,
+		}
+		if definition.Static != nil {
+			staticOptions.Index = definition.Static.Index
+			staticOptions.ListDirectories = definition.Static.ListDirectories
+			staticOptions.Precompressed = definition.Static.Precompressed
+			staticOptions.Fingerprint = definition.Static.Fingerprint
+		}
+
+		path := strings.TrimSuffix(definition.Template, fmt.Sprintf("{%s:.*}", StaticFileParam))
+
+		return g.addStaticRoute(definition.Name, path, fileSystem, staticOptions)
+	case RouteKindRedirect:
+		if g.factory.hasRouteName(definition.Name) {
+			return &RouteError{Route: definition.Name, Err: ErrDuplicateRoute}
+		}
+
+		if _, ok := g.factory.findRouteByName(definition.Target); !ok {
+			return &RouteError{Route: definition.Target, Err: ErrRouteNotFound}
+		}
+
+		route, err := g.createRedirectRoute(definition.Name, definition.Template, definition.Target, definition.Status, options)
+		if err != nil {
+			return err
+		}
+
+		return g.appendRoute(route)
+	case RouteKindAlias:
+		return g.addRouteAlias(definition.Name, definition.Template)
+	case RouteKindLocalized:
+		return g.addLocalizedRoute(definition.Name, definition.Locales, definition.Methods, action, LocalizedOptions{
+			Options:       options,
+			DefaultLocale: definition.DefaultLocale,
+		})
+	}
+
+	return &RouteError{Route: definition.Name, Err: fmt.Errorf(`unknown route kind "%s"`, definition.Kind)}
+}
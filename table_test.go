@@ -0,0 +1,358 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRouteTable_RoundTrip(t *testing.T) {
+	source, err := NewBuilder().SetBasePath("/app").SetStripBasePath(true).SetDefaultParamRequirement(`([a-z0-9]+)`).Build()
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	users, err := source.AddRouteGroup("users", "/users", Options{
+		Tags:     []string{"users"},
+		Metadata: Metadata{"rate_limit": 100},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddGetRoute("show", "/{id:[0-9]+}", "showUser")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.AddRoute("page", "/pages/{slug}", "", "showPage", Options{
+		Priority:      5,
+		DefaultParams: ParamsMap{"slug": "home"},
+		Deprecation:   &Deprecation{Sunset: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Successor: "users.show"},
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = users.AddMethodsRoute("update", "/{id:[0-9]+}", []string{http.MethodPut, http.MethodPatch}, "updateUser", Options{
+		Host:   "{tenant}.domain.com",
+		Secure: true,
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	data, err := MarshalRouteTable(source)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	table, err := UnmarshalRouteTable(data)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	exported, err := ExportRouteTable(source)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if !reflect.DeepEqual(table, exported) {
+		t.Errorf(`expected table %v but got %v`, exported, table)
+	}
+
+	restored, err := table.Build(map[string]Action{
+		"users.show":   "restoredShowUser",
+		"users.update": "restoredUpdateUser",
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		method string
+		url    string
+		name   string
+		action Action
+		params ParamsMap
+	}{
+		{method: http.MethodGet, url: "/app/users/10", name: "users.show", action: "restoredShowUser", params: ParamsMap{"id": "10"}},
+		{method: http.MethodPatch, url: "https://acme.domain.com/app/users/10", name: "users.update", action: "restoredUpdateUser", params: ParamsMap{"id": "10", "tenant": "acme"}},
+		{method: http.MethodPost, url: "/app/pages/about", name: "page", params: ParamsMap{"slug": "about"}},
+	}
+
+	for _, c := range cases {
+		match, err := restored.Match(httptest.NewRequest(c.method, c.url, nil))
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" for "%s" but got "%s"`, c.name, c.url, match.Route.Name())
+		}
+
+		if match.Route.Action() != c.action {
+			t.Errorf(`expected action %v for "%s" but got %v`, c.action, c.url, match.Route.Action())
+		}
+
+		if !reflect.DeepEqual(match.Params(), c.params) {
+			t.Errorf(`expected params %v for "%s" but got %v`, c.params, c.url, match.Params())
+		}
+	}
+
+	for _, name := range []string{"users.show", "users.update", "page"} {
+		expected, _ := source.FindRouteByName(name)
+		route, ok := restored.FindRouteByName(name)
+		if !ok {
+			t.Errorf(`expected route "%s" to be restored`, name)
+			continue
+		}
+
+		if route.Template() != expected.Template() || route.Priority() != expected.Priority() ||
+			!reflect.DeepEqual(route.Methods(), expected.Methods()) ||
+			!reflect.DeepEqual(route.DefaultParams(), expected.DefaultParams()) ||
+			!reflect.DeepEqual(route.Metadata(), expected.Metadata()) ||
+			!reflect.DeepEqual(route.Tags(), expected.Tags()) {
+			t.Errorf(`expected route %v but got %v`, expected, route)
+		}
+	}
+
+	route, _ := restored.FindRouteByName("users.show")
+	if _, err := route.URL(ParamsMap{"id": "abc"}); !errors.Is(err, ErrInvalidParam) {
+		t.Errorf(`expected error %v but got %v`, ErrInvalidParam, err)
+	}
+
+	again, err := MarshalRouteTable(restored)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if string(again) != string(data) {
+		t.Errorf(`expected stable output %s but got %s`, data, again)
+	}
+}
+
+func TestUnmarshalRouteTable(t *testing.T) {
+	cases := []struct {
+		data string
+		err  bool
+	}{
+		{data: `{"version": 1, "routes": [{"name": "home", "template": "/"}]}`},
+		{data: `{"version": 2, "routes": []}`, err: true},
+		{data: `{"version": 1, "routes": {}}`, err: true},
+		{data: `not json`, err: true},
+	}
+
+	for _, c := range cases {
+		_, err := UnmarshalRouteTable([]byte(c.data))
+		if (err != nil) != c.err {
+			t.Errorf(`expected error to be %t for %s but got %v`, c.err, c.data, err)
+		}
+	}
+
+	table, err := UnmarshalRouteTable([]byte(`{"version": 1, "routes": [{"name": "home", "template": "/{id:[0-9}"}]}`))
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, err := table.Build(nil); !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf(`expected error %v but got %v`, ErrInvalidPattern, err)
+	}
+}
+
+func TestRouteTable_RouteKinds(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte(request.URL.Path))
+	})
+	files := http.FS(fstest.MapFS{
+		"app.js": {Data: []byte("console.log(1)")},
+	})
+
+	source := New()
+
+	err := source.AddGetRoute("users", "/users/{id}", "users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.Mount("debug", "/debug", handler, MountOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.AddStaticRoute("assets", "/assets", files, StaticOptions{Fingerprint: true})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.AddRedirectRoute("members", "/members/{id}", "users", http.StatusFound)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.AddRouteAlias("users", "/people/{id}")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = source.AddLocalizedRoute("product", map[string]string{
+		"en": "/products/{slug}",
+		"de": "/produkte/{slug}",
+	}, []string{http.MethodGet}, "product", LocalizedOptions{DefaultLocale: "en"})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	data, err := MarshalRouteTable(source)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	table, err := UnmarshalRouteTable(data)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	restored, err := table.Build(map[string]Action{
+		"debug":  handler,
+		"assets": files,
+	})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url      string
+		name     string
+		code     int
+		body     string
+		location string
+	}{
+		{url: "/debug/pprof", name: "debug", code: http.StatusOK, body: "/pprof"},
+		{url: "/assets/app.js", name: "assets", code: http.StatusOK, body: "console.log(1)"},
+		{url: "/members/10", name: "members", code: http.StatusFound, location: "/users/10"},
+		{url: "/people/10", name: "users"},
+		{url: "/produkte/schuh", name: "product"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+
+		match, err := restored.Match(req)
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" for "%s" but got "%s"`, c.name, c.url, match.Route.Name())
+		}
+
+		handler, ok := match.Route.Action().(http.Handler)
+		if !ok {
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.code {
+			t.Errorf(`expected status %d for "%s" but got %d`, c.code, c.url, recorder.Code)
+		}
+
+		if c.body != "" && recorder.Body.String() != c.body {
+			t.Errorf(`expected body "%s" for "%s" but got "%s"`, c.body, c.url, recorder.Body.String())
+		}
+
+		if recorder.Header().Get("Location") != c.location {
+			t.Errorf(`expected location "%s" for "%s" but got "%s"`, c.location, c.url, recorder.Header().Get("Location"))
+		}
+	}
+
+	product, _ := restored.FindRouteByName("product")
+
+	result, err := product.URL(ParamsMap{"slug": "shoe", LocaleParam: "de"})
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	} else if result.String() != "/produkte/shoe" {
+		t.Errorf(`expected url "/produkte/shoe" but got "%s"`, result.String())
+	}
+
+	assets, _ := restored.FindRouteByName("assets")
+
+	result, err = assets.URL(ParamsMap{StaticFileParam: "app.js"})
+	if err != nil {
+		t.Errorf(`not expected error but got %v`, err)
+	} else if result.Query().Get(StaticFingerprintParam) == "" {
+		t.Errorf(`expected fingerprinted url but got "%s"`, result.String())
+	}
+
+	unbound, err := table.Build(nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	for _, url := range []string{"/debug/pprof", "/assets/app.js"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+
+		route, ok := unbound.FindRouteByRequest(req)
+		if !ok {
+			t.Errorf(`expected "%s" to be matched`, url)
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf(`expected status %d for "%s" without action but got %d`, http.StatusNotFound, url, recorder.Code)
+		}
+	}
+
+	again, err := MarshalRouteTable(restored)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if string(again) != string(data) {
+		t.Errorf(`expected stable output %s but got %s`, data, again)
+	}
+
+	_, err = source.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, err := ExportRouteTable(source); err == nil {
+		t.Error(`expected error for exporting versioned route group but got nil`)
+	}
+}
+
+func TestRouteDefinition_Restore(t *testing.T) {
+	r := New()
+
+	err := RouteDefinition{Name: "users", Template: "/users/{id}", Methods: []string{http.MethodGet}}.Restore(r, "users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = RouteDefinition{Name: "members", Kind: RouteKindRedirect, Template: "/members/{id}", Target: "users"}.Restore(r, nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = RouteDefinition{Name: "users", Template: "/people/{id}"}.Restore(r, nil)
+	if !errors.Is(err, ErrDuplicateRoute) {
+		t.Errorf(`expected error %v but got %v`, ErrDuplicateRoute, err)
+	}
+
+	route, ok := r.FindRouteByRequest(httptest.NewRequest(http.MethodGet, "/users/10", nil))
+	if !ok || route.Name() != "users" || route.Action() != "users" {
+		t.Errorf(`expected route "users" but got %v`, route)
+	}
+}
@@ -0,0 +1,223 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var paramNameMatcher = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type templateSegment struct {
+	value       string
+	param       bool
+	requirement string
+}
+
+type pathTemplate []templateSegment
+
+func parseTemplate(value string) (pathTemplate, error) {
+	var result pathTemplate
+	last := 0
+
+	for index := 0; index < len(value); index++ {
+		if value[index] != '{' {
+			continue
+		}
+
+		end, err := findPlaceholderEnd(value, index)
+		if err != nil {
+			return nil, err
+		}
+
+		segment, err := parsePlaceholder(value[index+1 : end])
+		if err != nil {
+			return nil, err
+		}
+
+		if last < index {
+			result = append(result, templateSegment{
+				value: value[last:index],
+			})
+		}
+
+		result = append(result, segment)
+		last = end + 1
+		index = end
+	}
+
+	if last < len(value) {
+		result = append(result, templateSegment{
+			value: value[last:],
+		})
+	}
+
+	return result, nil
+}
+
+func findPlaceholderEnd(value string, start int) (int, error) {
+	depth := 0
+
+	for index := start; index < len(value); index++ {
+		switch value[index] {
+		case '\\':
+			index++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return index, nil
+			}
+		}
+	}
+
+	return 0, errors.New("unclosed param is provided")
+}
+
+func parsePlaceholder(placeholder string) (templateSegment, error) {
+	name, requirement := placeholder, ""
+	if index := strings.Index(placeholder, ":"); index != -1 {
+		name, requirement = placeholder[:index], placeholder[index+1:]
+	}
+
+	if name == "" {
+		return templateSegment{}, errors.New("empty param is provided")
+	}
+
+	if !paramNameMatcher.MatchString(name) {
+		return templateSegment{}, fmt.Errorf(`invalid param name "%s" is provided`, name)
+	}
+
+	return templateSegment{
+		value:       name,
+		param:       true,
+		requirement: requirement,
+	}, nil
+}
+
+func (t pathTemplate) String() string {
+	var result strings.Builder
+
+	for _, segment := range t {
+		if segment.param {
+			result.WriteString("{")
+			result.WriteString(segment.value)
+			result.WriteString("}")
+			continue
+		}
+
+		result.WriteString(segment.value)
+	}
+
+	return result.String()
+}
+
+func (t pathTemplate) withPrefix(prefix string) pathTemplate {
+	if prefix == "" {
+		return t
+	}
+
+	result := make(pathTemplate, 0, len(t)+1)
+	result = append(result, templateSegment{
+		value: prefix,
+	})
+
+	return append(result, t...)
+}
+
+func (t pathTemplate) build(params ParamsMap, defaults paramsValues) (string, string) {
+	size, escapedSize := 0, 0
+
+	for _, segment := range t {
+		if !segment.param {
+			size += len(segment.value)
+			escapedSize += len(segment.value)
+			continue
+		}
+
+		value := lookupParam(params, defaults, segment.value)
+		size += len(value)
+		escapedSize += escapedParamLength(value)
+	}
+
+	var result strings.Builder
+
+	if size == escapedSize {
+		result.Grow(size)
+		t.write(&result, params, defaults, false)
+
+		path := result.String()
+		return path, path
+	}
+
+	result.Grow(size + escapedSize)
+	t.write(&result, params, defaults, false)
+	t.write(&result, params, defaults, true)
+
+	paths := result.String()
+	return paths[:size], paths[size:]
+}
+
+func (t pathTemplate) write(result *strings.Builder, params ParamsMap, defaults paramsValues, escape bool) {
+	for _, segment := range t {
+		if !segment.param {
+			result.WriteString(segment.value)
+			continue
+		}
+
+		value := lookupParam(params, defaults, segment.value)
+		if !escape {
+			result.WriteString(value)
+			continue
+		}
+
+		for index := 0; index < len(value); index++ {
+			c := value[index]
+			if c == '/' || !shouldEscapeParam(c) {
+				result.WriteByte(c)
+				continue
+			}
+
+			result.WriteByte('%')
+			result.WriteByte(upperHex[c>>4])
+			result.WriteByte(upperHex[c&15])
+		}
+	}
+}
+
+const upperHex = "0123456789ABCDEF"
+
+func lookupParam(params ParamsMap, defaults paramsValues, key string) string {
+	if value, ok := params[key]; ok {
+		return value
+	}
+
+	return defaults[key]
+}
+
+func escapedParamLength(value string) int {
+	length := len(value)
+
+	for index := 0; index < len(value); index++ {
+		if value[index] != '/' && shouldEscapeParam(value[index]) {
+			length += 2
+		}
+	}
+
+	return length
+}
+
+func shouldEscapeParam(c byte) bool {
+	if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+		return false
+	}
+
+	switch c {
+	case '-', '_', '.', '~', '$', '&', '+', ':', '=', '@':
+		return false
+	}
+
+	return true
+}
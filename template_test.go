@@ -0,0 +1,135 @@
+package router
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	cases := []struct {
+		value  string
+		result pathTemplate
+		err    bool
+	}{
+		{
+			value: "/",
+			result: pathTemplate{
+				{value: "/"},
+			},
+		},
+		{
+			value: "/users/{id}",
+			result: pathTemplate{
+				{value: "/users/"},
+				{value: "id", param: true},
+			},
+		},
+		{
+			value: "/users/{id:[0-9]+}/{_format:json|xml}",
+			result: pathTemplate{
+				{value: "/users/"},
+				{value: "id", param: true, requirement: "[0-9]+"},
+				{value: "/"},
+				{value: "_format", param: true, requirement: "json|xml"},
+			},
+		},
+		{
+			value: "/archive/{year:[0-9]{4}}-{month:[0-9]{2}}.html",
+			result: pathTemplate{
+				{value: "/archive/"},
+				{value: "year", param: true, requirement: "[0-9]{4}"},
+				{value: "-"},
+				{value: "month", param: true, requirement: "[0-9]{2}"},
+				{value: ".html"},
+			},
+		},
+		{
+			value: "/users/{id",
+			err:   true,
+		},
+		{
+			value: "/users/{}",
+			err:   true,
+		},
+		{
+			value: "/users/{:[0-9]+}",
+			err:   true,
+		},
+		{
+			value: "/users/{1d}",
+			err:   true,
+		},
+	}
+
+	for _, c := range cases {
+		result, err := parseTemplate(c.value)
+		if c.err {
+			if err == nil {
+				t.Errorf(`expected error for template "%s" but got nil`, c.value)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		} else if !reflect.DeepEqual(result, c.result) {
+			t.Errorf(`expected segments %v but got %v`, c.result, result)
+		}
+	}
+}
+
+func TestPathTemplate_String(t *testing.T) {
+	segments, err := parseTemplate("/archive/{year:[0-9]{4}}/{slug}")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if segments.String() != "/archive/{year}/{slug}" {
+		t.Errorf(`expected path "/archive/{year}/{slug}" but got "%s"`, segments.String())
+	}
+}
+
+func TestPathTemplate_build(t *testing.T) {
+	cases := []struct {
+		template string
+		params   ParamsMap
+		defaults paramsValues
+		path     string
+		rawPath  string
+	}{
+		{template: "/users/{id}", params: ParamsMap{"id": "10"}, path: "/users/10", rawPath: "/users/10"},
+		{template: "/users/{id}", defaults: paramsValues{"id": "1"}, path: "/users/1", rawPath: "/users/1"},
+		{template: "/users/{id}", params: ParamsMap{"id": "10"}, defaults: paramsValues{"id": "1"}, path: "/users/10", rawPath: "/users/10"},
+		{template: "/files/{path}", params: ParamsMap{"path": "a b/c?d"}, path: "/files/a b/c?d", rawPath: "/files/a%20b/c%3Fd"},
+		{template: "/tags/{tag}", params: ParamsMap{"tag": "$&+:=@-_.~"}, path: "/tags/$&+:=@-_.~", rawPath: "/tags/$&+:=@-_.~"},
+		{template: "/tags/{tag}", params: ParamsMap{"tag": "ü;,#%"}, path: "/tags/ü;,#%", rawPath: "/tags/%C3%BC%3B%2C%23%25"},
+	}
+
+	for _, c := range cases {
+		segments, err := parseTemplate(c.template)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		path, rawPath := segments.build(c.params, c.defaults)
+		if path != c.path {
+			t.Errorf(`expected path "%s" but got "%s"`, c.path, path)
+		}
+
+		if rawPath != c.rawPath {
+			t.Errorf(`expected raw path "%s" but got "%s"`, c.rawPath, rawPath)
+		}
+	}
+}
+
+func TestShouldEscapeParam(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		value := string([]byte{byte(c)})
+		expected := url.PathEscape(value) != value
+
+		if shouldEscapeParam(byte(c)) != expected {
+			t.Errorf(`expected escaping of %q to be %t`, value, expected)
+		}
+	}
+}
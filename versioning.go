@@ -0,0 +1,378 @@
+package router
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	pathLib "path"
+	"strings"
+)
+
+type VersionOptions struct {
+	Options
+	Header         string
+	MediaTypeParam string
+	DefaultVersion string
+}
+
+type versionedRouteGroup struct {
+	base      *routeGroup
+	options   VersionOptions
+	fallbacks []*routeGroup
+	inherited map[*routeGroup]map[*route]*route
+}
+
+var _ VersionedRouteGroup = &versionedRouteGroup{}
+var _ routeFinder = &versionedRouteGroup{}
+
+type rewrittenRoute struct {
+	Route
+	rewrite func(request *http.Request) *http.Request
+}
+
+var _ Route = &rewrittenRoute{}
+
+func (g *routeGroup) AddVersionedRouteGroup(name string, path string, options VersionOptions) (VersionedRouteGroup, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	finalName := fmt.Sprintf("%s%s", g.getPrefix(), name)
+
+	if g.factory.hasRouteName(finalName) {
+		return nil, &RouteError{Route: finalName, Err: ErrDuplicateRoute}
+	}
+
+	base, err := g.factory.createRouteGroup(finalName, pathLib.Join(g.originalPath, path), g.getOptions(options.Options))
+	if err != nil {
+		return nil, err
+	}
+
+	group := &versionedRouteGroup{
+		base:    base,
+		options: options,
+	}
+
+	err = g.appendRoute(group)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (v *versionedRouteGroup) AddVersion(version string, options Options) (RouteGroup, error) {
+	v.base.lock.Lock()
+	defer v.base.lock.Unlock()
+
+	if version == "" || strings.ContainsAny(version, "/.{}") {
+		return nil, &RouteError{Route: v.base.name, Err: fmt.Errorf(`invalid version "%s"`, version)}
+	}
+
+	if findVersion(v.versions(), version) != -1 {
+		return nil, &RouteError{Route: v.base.getPrefix() + version, Err: ErrDuplicateRoute}
+	}
+
+	group, err := v.base.addRouteGroup(version, "/"+version, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (v *versionedRouteGroup) Versions() []string {
+	v.base.lock.RLock()
+	defer v.base.lock.RUnlock()
+
+	return v.versions()
+}
+
+func (v *versionedRouteGroup) versions() []string {
+	result := make([]string, 0, len(v.base.routes))
+	for _, r := range v.base.routes {
+		result = append(result, strings.TrimPrefix(r.(*routeGroup).name, v.base.getPrefix()))
+	}
+
+	return result
+}
+
+func (v *versionedRouteGroup) findRouteByRequest(request *http.Request) (Route, bool) {
+	match, ok := v.matchRequest(request)
+	if !ok {
+		return nil, false
+	}
+
+	return match.getRoute(), true
+}
+
+func (v *versionedRouteGroup) findRouteByName(name string) (Route, bool) {
+	if !strings.HasPrefix(name, v.base.getPrefix()) {
+		return nil, false
+	}
+
+	if route, ok := v.base.findRouteByName(name); ok {
+		return route, true
+	}
+
+	for _, fallback := range v.fallbacks {
+		if route, ok := fallback.findRouteByName(name); ok {
+			return route, true
+		}
+	}
+
+	return nil, false
+}
+
+func (v *versionedRouteGroup) matchRequest(request *http.Request) (routeMatch, bool) {
+	if request == nil || request.URL == nil {
+		return routeMatch{}, false
+	}
+
+	if !v.base.matchesPath(request.URL) {
+		return routeMatch{}, false
+	}
+
+	fallbacks := v.fallbacks
+
+	for index, r := range v.base.routes {
+		if match, ok := r.matchRequest(request); ok {
+			return match, true
+		}
+
+		if match, ok := fallbacks[index].matchRequest(request); ok {
+			return match, true
+		}
+	}
+
+	return v.matchUnversionedRequest(request, fallbacks)
+}
+
+func (v *versionedRouteGroup) matchUnversionedRequest(request *http.Request, fallbacks []*routeGroup) (routeMatch, bool) {
+	if v.options.Header == "" && v.options.MediaTypeParam == "" && v.options.DefaultVersion == "" {
+		return routeMatch{}, false
+	}
+
+	versions := v.versions()
+
+	index := v.selectVersion(request, versions)
+	if index == -1 {
+		return routeMatch{}, false
+	}
+
+	rewrite := v.createRewrite(versions[index])
+	rewritten := rewrite(request)
+
+	match, ok := v.base.routes[index].matchRequest(rewritten)
+	if !ok {
+		match, ok = fallbacks[index].matchRequest(rewritten)
+	}
+
+	if !ok {
+		return routeMatch{}, false
+	}
+
+	match.result = &rewrittenRoute{
+		Route:   match.getRoute(),
+		rewrite: rewrite,
+	}
+
+	return match, true
+}
+
+func (v *versionedRouteGroup) allowedMethods(request *http.Request, methods methodsList) methodsList {
+	methods = v.base.allowedMethods(request, methods)
+
+	for _, fallback := range v.fallbacks {
+		methods = fallback.allowedMethods(request, methods)
+	}
+
+	return methods
+}
+
+func (v *versionedRouteGroup) selectVersion(request *http.Request, versions []string) int {
+	if v.options.Header != "" {
+		if index := findVersion(versions, request.Header.Get(v.options.Header)); index != -1 {
+			return index
+		}
+	}
+
+	if v.options.MediaTypeParam != "" {
+		for _, accept := range strings.Split(request.Header.Get("Accept"), ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+			if err != nil {
+				continue
+			}
+
+			if index := findVersion(versions, params[v.options.MediaTypeParam]); index != -1 {
+				return index
+			}
+		}
+	}
+
+	if v.options.DefaultVersion != "" {
+		return findVersion(versions, v.options.DefaultVersion)
+	}
+
+	return len(versions) - 1
+}
+
+func findVersion(versions []string, version string) int {
+	if version == "" {
+		return -1
+	}
+
+	for index, value := range versions {
+		if value == version || value == "v"+version {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (v *versionedRouteGroup) createRewrite(version string) func(request *http.Request) *http.Request {
+	return func(request *http.Request) *http.Request {
+		path, ok := v.base.basePath.strip(request.URL.Path)
+		if !ok {
+			return request
+		}
+
+		indexes := v.base.forwardRegexp.FindStringIndex(path)
+		if indexes == nil {
+			return request
+		}
+
+		path = strings.TrimSuffix(path[:indexes[1]], "/") + "/" + version + path[indexes[1]:]
+
+		rewritten := new(http.Request)
+		*rewritten = *request
+		rewritten.URL = new(url.URL)
+		*rewritten.URL = *request.URL
+		rewritten.URL.Path = path
+		rewritten.URL.RawPath = ""
+
+		if v.base.basePath.stripPrefix {
+			rewritten.URL.Path = v.base.basePath.apply(path)
+		}
+
+		return rewritten
+	}
+}
+
+// buildFallbacks creates the routes each version inherits from the previous
+// ones, so requests are matched without building them on the fly. Routes
+// inherited from unchanged sources are reused from the previous build.
+func (v *versionedRouteGroup) buildFallbacks() error {
+	fallbacks := make([]*routeGroup, 0, len(v.base.routes))
+	inherited := make(map[*routeGroup]map[*route]*route, len(v.base.routes))
+
+	for index := range v.base.routes {
+		fallback, clones, err := v.createFallback(index)
+		if err != nil {
+			return err
+		}
+
+		fallbacks = append(fallbacks, fallback)
+		inherited[v.base.routes[index].(*routeGroup)] = clones
+	}
+
+	v.fallbacks = fallbacks
+	v.inherited = inherited
+
+	return nil
+}
+
+func (v *versionedRouteGroup) createFallback(index int) (*routeGroup, map[*route]*route, error) {
+	target := v.base.routes[index].(*routeGroup)
+	previous := v.inherited[target]
+
+	fallback := &routeGroup{
+		name:          target.name,
+		host:          target.host,
+		secure:        target.secure,
+		forwardRegexp: target.forwardRegexp,
+		reversePath:   target.reversePath,
+		originalPath:  target.originalPath,
+		defaultParams: target.defaultParams,
+		factory:       target.factory,
+		basePath:      target.basePath,
+		lock:          target.lock,
+		metadata:      target.metadata,
+		tags:          target.tags,
+		deprecation:   target.deprecation,
+	}
+
+	defined := map[string]bool{}
+	for _, r := range target.collectRoutes(nil) {
+		defined[strings.TrimPrefix(r.name, target.getPrefix())] = true
+	}
+
+	clones := map[*route]*route{}
+
+	for previousIndex := index - 1; previousIndex >= 0; previousIndex-- {
+		source := v.base.routes[previousIndex].(*routeGroup)
+
+		for _, r := range source.collectRoutes(nil) {
+			name := strings.TrimPrefix(r.name, source.getPrefix())
+			if defined[name] {
+				continue
+			}
+
+			inherited, ok := previous[r]
+			if !ok {
+				var err error
+
+				inherited, err = v.createInheritedRoute(fallback, source, target, r)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			clones[r] = inherited
+			fallback.routes = append(fallback.routes, inherited)
+			defined[name] = true
+		}
+	}
+
+	return fallback, clones, nil
+}
+
+func (v *versionedRouteGroup) createInheritedRoute(fallback *routeGroup, source *routeGroup, target *routeGroup, r *route) (*route, error) {
+	name := strings.TrimPrefix(r.name, source.getPrefix())
+	path := strings.TrimPrefix(r.Template(), strings.TrimSuffix(source.originalPath, "/"))
+
+	inherited, err := fallback.createClone(target.getPrefix()+name, path, r, func(name string) string {
+		if !strings.HasPrefix(name, source.getPrefix()) {
+			return name
+		}
+
+		return target.getPrefix() + strings.TrimPrefix(name, source.getPrefix())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// inherited routes keep the position of their source, so the order does
+	// not change when fallbacks are rebuilt
+	inherited.order.sequence = r.order.sequence
+
+	return inherited, nil
+}
+
+func (r *rewrittenRoute) ExtractParams(request *http.Request) (ParamsMap, error) {
+	return r.Route.ExtractParams(r.rewrite(request))
+}
+
+func (r *rewrittenRoute) ExtractPathParams(request *http.Request) (ParamsMap, error) {
+	return r.Route.ExtractPathParams(r.rewrite(request))
+}
+
+func (r *rewrittenRoute) generateURL(params ParamsMap, referenceType ReferenceType, context urlContext) (*url.URL, error) {
+	generator, ok := r.Route.(contextURLGenerator)
+	if !ok {
+		return r.Route.GenerateURL(params, referenceType)
+	}
+
+	return generator.generateURL(params, referenceType, context)
+}
@@ -0,0 +1,412 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestVersionedRouteGroup_Match(t *testing.T) {
+	cases := []struct {
+		options VersionOptions
+		url     string
+		header  http.Header
+		found   bool
+		name    string
+		action  string
+		params  ParamsMap
+	}{
+		{url: "/api/v1/users/10", found: true, name: "api.v1.users", action: "v1.users", params: ParamsMap{"id": "10"}},
+		{url: "/api/v2/users/10", found: true, name: "api.v2.users", action: "v2.users", params: ParamsMap{"id": "10"}},
+		{url: "/api/v2/posts", found: true, name: "api.v2.posts", action: "v1.posts", params: ParamsMap{}},
+		{url: "/api/v3/posts", found: false},
+		{url: "/api/users/10", found: false},
+		{
+			options: VersionOptions{Header: "X-API-Version"},
+			url:     "/api/users/10",
+			header:  http.Header{"X-Api-Version": {"v1"}},
+			found:   true,
+			name:    "api.v1.users",
+			action:  "v1.users",
+			params:  ParamsMap{"id": "10"},
+		},
+		{
+			options: VersionOptions{Header: "X-API-Version"},
+			url:     "/api/users/10",
+			found:   true,
+			name:    "api.v2.users",
+			action:  "v2.users",
+			params:  ParamsMap{"id": "10"},
+		},
+		{
+			options: VersionOptions{MediaTypeParam: "version"},
+			url:     "/api/users/10",
+			header:  http.Header{"Accept": {"application/json; version=1"}},
+			found:   true,
+			name:    "api.v1.users",
+			action:  "v1.users",
+			params:  ParamsMap{"id": "10"},
+		},
+		{
+			options: VersionOptions{MediaTypeParam: "version"},
+			url:     "/api/posts",
+			header:  http.Header{"Accept": {"text/html, application/json; version=2"}},
+			found:   true,
+			name:    "api.v2.posts",
+			action:  "v1.posts",
+			params:  ParamsMap{},
+		},
+		{
+			options: VersionOptions{DefaultVersion: "v1"},
+			url:     "/api/users/10",
+			found:   true,
+			name:    "api.v1.users",
+			action:  "v1.users",
+			params:  ParamsMap{"id": "10"},
+		},
+	}
+
+	for _, c := range cases {
+		r := New()
+
+		api, err := r.AddVersionedRouteGroup("api", "/api", c.options)
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		v1, err := api.AddVersion("v1", Options{})
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		v2, err := api.AddVersion("v2", Options{})
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		err = v1.AddGetRoute("users", "/users/{id:[0-9]+}", "v1.users")
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		err = v1.AddGetRoute("posts", "/posts", "v1.posts")
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		err = v2.AddGetRoute("users", "/users/{id:[0-9]+}", "v2.users")
+		if err != nil {
+			t.Fatalf(`not expected error but got %v`, err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+		for key, values := range c.header {
+			req.Header[key] = values
+		}
+
+		match, err := r.Match(req)
+		if !c.found {
+			if err == nil {
+				t.Errorf(`expected "%s" not to be matched`, c.url)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		if match.Route.Name() != c.name {
+			t.Errorf(`expected route "%s" but got "%s"`, c.name, match.Route.Name())
+		}
+
+		if match.Route.Action() != c.action {
+			t.Errorf(`expected action "%s" but got %v`, c.action, match.Route.Action())
+		}
+
+		if !reflect.DeepEqual(match.PathParams, c.params) {
+			t.Errorf(`expected params %v but got %v`, c.params, match.PathParams)
+		}
+
+		params, err := match.Route.ExtractPathParams(req)
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+		}
+
+		if !reflect.DeepEqual(params, c.params) {
+			t.Errorf(`expected extracted params %v but got %v`, c.params, params)
+		}
+	}
+}
+
+func TestVersionedRouteGroup_FindRouteByName(t *testing.T) {
+	cases := []struct {
+		name  string
+		found bool
+		url   string
+	}{
+		{name: "api.v1.users", found: true, url: "/api/v1/users/10"},
+		{name: "api.v2.users", found: true, url: "/api/v2/users/10"},
+		{name: "api.v2.posts", found: true, url: "/api/v2/posts"},
+		{name: "api.v3.posts", found: false},
+		{name: "api.posts", found: false},
+	}
+
+	r := New()
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v2, err := api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("users", "/users/{id:[0-9]+}", "v1.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("posts", "/posts", "v1.posts")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v2.AddGetRoute("users", "/users/{id:[0-9]+}", "v2.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if !reflect.DeepEqual(api.Versions(), []string{"v1", "v2"}) {
+		t.Errorf(`expected versions %v but got %v`, []string{"v1", "v2"}, api.Versions())
+	}
+
+	for _, c := range cases {
+		route, ok := r.FindRouteByName(c.name)
+		if ok != c.found {
+			t.Errorf(`expected found to be %t for "%s" but got %t`, c.found, c.name, ok)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		result, err := route.URL(ParamsMap{"id": "10"})
+		if err != nil {
+			t.Errorf(`not expected error but got %v`, err)
+			continue
+		}
+
+		if result.String() != c.url {
+			t.Errorf(`expected url "%s" but got "%s"`, c.url, result.String())
+		}
+	}
+}
+
+func TestVersionedRouteGroup_Override(t *testing.T) {
+	r := New()
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v2, err := api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("users", "/users/{id:[0-9]+}", "v1.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("posts", "/posts", "v1.posts")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v2.AddGetRoute("users", "/users/{id:[0-9]+}", "v2.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v3, err := api.AddVersion("v3", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok := r.FindRouteByName("api.v3.users")
+	if !ok || route.Action() != "v2.users" {
+		t.Errorf(`expected inherited action "v2.users" but got %v`, route)
+	}
+
+	err = v3.AddGetRoute("users", "/users/{id:[0-9]+}", "v3.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	route, ok = r.FindRouteByName("api.v3.users")
+	if !ok || route.Action() != "v3.users" {
+		t.Errorf(`expected action "v3.users" but got %v`, route)
+	}
+
+	err = r.RemoveRoute("api.v1.posts")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if _, ok := r.FindRouteByName("api.v3.posts"); ok {
+		t.Error(`expected "api.v3.posts" to be removed together with "api.v1.posts"`)
+	}
+
+	if _, err := api.AddVersion("v1", Options{}); err == nil {
+		t.Error(`expected error for duplicate version but got nil`)
+	}
+
+	if _, err := api.AddVersion("v.4", Options{}); err == nil {
+		t.Error(`expected error for invalid version but got nil`)
+	}
+}
+
+func TestVersionedRouteGroup_RebuildFallbacks(t *testing.T) {
+	r := New()
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	_, err = api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("users", "/users/{id:[0-9]+}", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	fallback := api.(*versionedRouteGroup).fallbacks[1]
+
+	source, _ := r.FindRouteByName("api.v1.users")
+	inherited, _ := r.FindRouteByName("api.v2.users")
+
+	if inherited.(*route).order.sequence != source.(*route).order.sequence {
+		t.Errorf(`expected inherited route to keep sequence %d but got %d`, source.(*route).order.sequence, inherited.(*route).order.sequence)
+	}
+
+	err = r.AddGetRoute("home", "/", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if api.(*versionedRouteGroup).fallbacks[1] != fallback {
+		t.Error(`expected fallbacks not to be rebuilt for a change outside the versioned group`)
+	}
+
+	err = v1.AddGetRoute("posts", "/posts", nil)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	if route, _ := r.FindRouteByName("api.v2.users"); route != inherited {
+		t.Errorf(`expected inherited route to be reused but got %v`, route)
+	}
+
+	if _, ok := r.FindRouteByName("api.v2.posts"); !ok {
+		t.Error(`expected "api.v2.posts" to be inherited`)
+	}
+}
+
+func TestVersionedRouteGroup_InheritHandlers(t *testing.T) {
+	r := New()
+
+	api, err := r.AddVersionedRouteGroup("api", "/api", VersionOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	v1, err := api.AddVersion("v1", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddGetRoute("users", "/users/{id:[0-9]+}", "v1.users")
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddRedirectRoute("members", "/members/{id:[0-9]+}", "users", http.StatusFound)
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	err = v1.AddStaticRoute("assets", "/assets", http.FS(fstest.MapFS{
+		"app.js": {Data: []byte("console.log(1)")},
+	}), StaticOptions{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	_, err = api.AddVersion("v2", Options{})
+	if err != nil {
+		t.Fatalf(`not expected error but got %v`, err)
+	}
+
+	cases := []struct {
+		url      string
+		code     int
+		body     string
+		location string
+	}{
+		{url: "/api/v2/assets/app.js", code: http.StatusOK, body: "console.log(1)"},
+		{url: "/api/v2/members/10", code: http.StatusFound, location: "/api/v2/users/10"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.url, nil)
+
+		match, err := r.Match(req)
+		if err != nil {
+			t.Errorf(`not expected error for "%s" but got %v`, c.url, err)
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		match.Route.Action().(http.Handler).ServeHTTP(recorder, req)
+
+		if recorder.Code != c.code {
+			t.Errorf(`expected status %d for "%s" but got %d`, c.code, c.url, recorder.Code)
+		}
+
+		if c.body != "" && recorder.Body.String() != c.body {
+			t.Errorf(`expected body "%s" for "%s" but got "%s"`, c.body, c.url, recorder.Body.String())
+		}
+
+		if recorder.Header().Get("Location") != c.location {
+			t.Errorf(`expected location "%s" for "%s" but got "%s"`, c.location, c.url, recorder.Header().Get("Location"))
+		}
+	}
+}